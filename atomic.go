@@ -0,0 +1,29 @@
+package uid
+
+import "sync/atomic"
+
+// EnsureV7 lazily initializes a shared ID field: it stores a fresh version 7
+// UUID in p only if p is nil, and returns whichever value ends up stored.
+// Concurrent callers all receive the same ID.
+//
+// Example:
+//
+//	var requestID atomic.Pointer[string]
+//	id := EnsureV7(&requestID) // same value on every call
+//
+// Parameters:
+// - p: the pointer to initialize, must not itself be nil
+// - formatted: when true, a newly generated ID includes hyphens
+//
+// Returns:
+// - The stored ID
+func EnsureV7(p *atomic.Pointer[string], formatted ...bool) string {
+	if v := p.Load(); v != nil {
+		return *v
+	}
+	id := UuidV7(formatted...)
+	if p.CompareAndSwap(nil, &id) {
+		return id
+	}
+	return *p.Load()
+}
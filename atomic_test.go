@@ -0,0 +1,52 @@
+package uid
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+)
+
+func TestEnsureV7(t *testing.T) {
+	var p atomic.Pointer[string]
+	first := EnsureV7(&p, true)
+	assertLenAndVersion(t, first, 36, '7', true)
+	if again := EnsureV7(&p, true); again != first {
+		t.Fatalf("EnsureV7 replaced %q with %q", first, again)
+	}
+	if *p.Load() != first {
+		t.Fatalf("stored %q, want %q", *p.Load(), first)
+	}
+}
+
+func TestEnsureV7_KeepsExisting(t *testing.T) {
+	var p atomic.Pointer[string]
+	existing := "existing"
+	p.Store(&existing)
+	if got := EnsureV7(&p); got != existing {
+		t.Fatalf("EnsureV7 = %q, want %q", got, existing)
+	}
+}
+
+func TestEnsureV7_Concurrent(t *testing.T) {
+	var p atomic.Pointer[string]
+	const n = 64
+	results := make([]string, n)
+	var start, wg sync.WaitGroup
+	start.Add(1)
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			start.Wait()
+			results[i] = EnsureV7(&p)
+		}(i)
+	}
+	start.Done()
+	wg.Wait()
+	winner := *p.Load()
+	for i, r := range results {
+		if r != winner {
+			t.Fatalf("goroutine %d saw %q, stored value is %q", i, r, winner)
+		}
+	}
+}
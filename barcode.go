@@ -0,0 +1,96 @@
+package uid
+
+import "encoding/binary"
+
+// Layout of BarcodeID: 9 Base32 characters of Unix milliseconds, 10 of
+// randomness and one check character.
+const (
+	barcodeTimeChars   = 9
+	barcodeRandomChars = 10
+	barcodeLength      = barcodeTimeChars + barcodeRandomChars + 1
+)
+
+// BarcodeID returns a 20-character uppercase Crockford Base32 ID for
+// warehouse barcodes: no hyphens, no ambiguous letters (I, L, O, U) and a
+// trailing check character that catches any single mistyped character and
+// most swaps of adjacent characters.
+//
+// Layout:
+//   - characters 1-9: Unix time in milliseconds (45 bits, until year 3084),
+//     so IDs sort by creation time at millisecond resolution
+//   - characters 10-19: 50 random bits
+//   - character 20: Luhn mod 32 check character over the first 19
+//
+// Example: 1M53SA8Y4PQCKYYHHKGF (length: 20)
+//
+// Parameters:
+// - None
+//
+// Returns:
+// - The barcode ID, or the error of the random source
+func BarcodeID() (string, error) {
+	var r [8]byte
+	if err := readRandom(r[:]); err != nil {
+		return "", err
+	}
+	ms := uint64(nowFunc().UnixMilli()) & (1<<(5*barcodeTimeChars) - 1)
+	random := binary.BigEndian.Uint64(r[:]) & (1<<(5*barcodeRandomChars) - 1)
+
+	b := make([]byte, barcodeLength)
+	putCrockfordUint(b[:barcodeTimeChars], ms)
+	putCrockfordUint(b[barcodeTimeChars:barcodeLength-1], random)
+	b[barcodeLength-1] = crockfordAlphabet[luhn32Check(b[:barcodeLength-1])]
+	return string(b), nil
+}
+
+// VerifyBarcodeID reports whether s is a well-formed BarcodeID with a
+// matching check character. Input is case-insensitive, and the Crockford
+// aliases I/L (1) and O (0) are accepted, as scanned or typed text may use
+// them.
+//
+// Parameters:
+// - s: the candidate barcode ID
+//
+// Returns:
+// - true if s has the right length and alphabet and its check character matches
+func VerifyBarcodeID(s string) bool {
+	if len(s) != barcodeLength {
+		return false
+	}
+	sum := 0
+	factor := 1
+	for i := len(s) - 1; i >= 0; i-- {
+		d := crockfordDigit(s[i])
+		if d < 0 {
+			return false
+		}
+		sum += luhnAddend(d, factor)
+		factor = 3 - factor
+	}
+	return sum%32 == 0
+}
+
+// putCrockfordUint writes v into b as big-endian Crockford Base32 digits.
+func putCrockfordUint(b []byte, v uint64) {
+	for i := len(b) - 1; i >= 0; i-- {
+		b[i] = crockfordAlphabet[v&31]
+		v >>= 5
+	}
+}
+
+// luhn32Check returns the Luhn mod 32 check digit of payload.
+func luhn32Check(payload []byte) int {
+	sum := 0
+	factor := 2
+	for i := len(payload) - 1; i >= 0; i-- {
+		sum += luhnAddend(crockfordDigit(payload[i]), factor)
+		factor = 3 - factor
+	}
+	return (32 - sum%32) % 32
+}
+
+// luhnAddend returns the sum of the base 32 digits of d*factor.
+func luhnAddend(d, factor int) int {
+	a := d * factor
+	return a/32 + a%32
+}
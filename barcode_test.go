@@ -0,0 +1,70 @@
+package uid
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestBarcodeID(t *testing.T) {
+	for i := 0; i < 100; i++ {
+		s, err := BarcodeID()
+		if err != nil {
+			t.Fatalf("BarcodeID error: %v", err)
+		}
+		if len(s) != 20 || s != strings.ToUpper(s) {
+			t.Fatalf("BarcodeID = %q, want 20 uppercase characters", s)
+		}
+		if strings.ContainsAny(s, "ILOU-") {
+			t.Fatalf("BarcodeID = %q contains an excluded character", s)
+		}
+		if !VerifyBarcodeID(s) {
+			t.Fatalf("VerifyBarcodeID(%q) = false", s)
+		}
+		if !VerifyBarcodeID(strings.ToLower(s)) {
+			t.Fatalf("VerifyBarcodeID rejected lowercase %q", s)
+		}
+	}
+}
+
+func TestVerifyBarcodeID_Corrupted(t *testing.T) {
+	s, err := BarcodeID()
+	if err != nil {
+		t.Fatalf("BarcodeID error: %v", err)
+	}
+	for i := 0; i < len(s); i++ {
+		for j := 0; j < len(crockfordAlphabet); j++ {
+			c := crockfordAlphabet[j]
+			if c == s[i] {
+				continue
+			}
+			bad := s[:i] + string(c) + s[i+1:]
+			if VerifyBarcodeID(bad) {
+				t.Fatalf("corrupted %q (index %d) passed verification", bad, i)
+			}
+		}
+	}
+	for _, bad := range []string{"", s[:19], s + "0", s[:19] + "U", s[:5] + "-" + s[6:]} {
+		if VerifyBarcodeID(bad) {
+			t.Fatalf("VerifyBarcodeID(%q) = true", bad)
+		}
+	}
+}
+
+func TestBarcodeID_OrderedByTime(t *testing.T) {
+	base := time.Date(2024, 6, 18, 0, 0, 0, 0, time.UTC)
+	defer func() { nowFunc = time.Now }()
+	prev := ""
+	for i := 0; i < 50; i++ {
+		at := base.Add(time.Duration(i) * time.Millisecond)
+		nowFunc = func() time.Time { return at }
+		s, err := BarcodeID()
+		if err != nil {
+			t.Fatalf("BarcodeID error: %v", err)
+		}
+		if s[:9] <= prev {
+			t.Fatalf("time prefix %q does not sort after %q", s[:9], prev)
+		}
+		prev = s[:9]
+	}
+}
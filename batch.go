@@ -0,0 +1,129 @@
+package uid
+
+import "context"
+
+// AssignV7 fills every non-nil pointer in dst with a fresh version 7 UUID.
+// The assigned values are strictly increasing in slice order, so a batch
+// insert receives ordered keys in one call. Nil pointers are skipped.
+//
+// Example:
+//
+//	ids := []*string{&a.ID, &b.ID, &c.ID}
+//	uid.AssignV7(ids) // a.ID < b.ID < c.ID
+//
+// Parameters:
+// - dst: the pointers to assign; nil entries are left untouched
+// - formatted: when true, include hyphens
+func AssignV7(dst []*string, formatted ...bool) {
+	withHyphens := len(formatted) > 0 && formatted[0]
+	for _, p := range dst {
+		if p == nil {
+			continue
+		}
+		// newV7 is monotonic, so consecutive values are strictly increasing
+		*p = bytesToUUIDString(newV7(), withHyphens)
+	}
+}
+
+// UniqueV4Set returns n random UUIDs (version 4, without hyphens) that are
+// distinct from each other and absent from existing. A collision is
+// astronomically unlikely; this merely makes fixture seeding airtight.
+//
+// Parameters:
+// - n: the number of UUIDs to generate; n <= 0 yields an empty slice
+// - existing: UUIDs already in use, in bare or hyphenated lowercase form;
+// it is not modified and may be nil
+//
+// Returns:
+// - The new UUIDs
+func UniqueV4Set(n int, existing map[string]bool) []string {
+	if n <= 0 {
+		return []string{}
+	}
+	ids := make([]string, 0, n)
+	seen := make(map[string]bool, n)
+	for len(ids) < n {
+		b := newV4()
+		id := bytesToUUIDString(b, false)
+		if seen[id] || existing[encodeUUID(b, false, false)] || existing[canonicalString(b)] {
+			continue
+		}
+		seen[id] = true
+		ids = append(ids, id)
+	}
+	return ids
+}
+
+// UuidV4Batch returns n random UUIDs (version 4), drawing the randomness
+// for all of them in a single read instead of one read per UUID, which is
+// faster for seeding jobs that need many IDs.
+//
+// Parameters:
+// - n: the number of UUIDs; n <= 0 yields an empty slice
+// - formatted: when true, include hyphens
+//
+// Returns:
+// - The UUIDs
+func UuidV4Batch(n int, formatted ...bool) []string {
+	if n <= 0 {
+		return []string{}
+	}
+	withHyphens := len(formatted) > 0 && formatted[0]
+	ids := make([]string, n)
+	buf := make([]byte, 16*n)
+	if err := readRandom(buf); err != nil {
+		// fall back to newV4 per UUID, which degrades the same way UuidV4 does
+		for i := range ids {
+			ids[i] = bytesToUUIDString(newV4(), withHyphens)
+		}
+		return ids
+	}
+	for i := range ids {
+		b := buf[16*i : 16*(i+1)]
+		setVersion(b, 4)
+		setVariantRFC4122(b)
+		ids[i] = bytesToUUIDString(b, withHyphens)
+	}
+	return ids
+}
+
+// batchChunk is the number of UUIDs UuidV4BatchContext generates between
+// two context checks.
+const batchChunk = 1024
+
+// UuidV4BatchContext is UuidV4Batch for very large batches in request
+// handlers: it generates the UUIDs in chunks of 1024, one random read per
+// chunk, and stops as soon as ctx is done.
+//
+// Parameters:
+// - ctx: checked before each chunk
+// - n: the number of UUIDs; n <= 0 yields an empty slice
+// - formatted: when true, include hyphens
+//
+// Returns:
+// - The UUIDs generated so far: all n on success, fewer on error
+// - ctx.Err() if the context ended first, or the error of the random source
+func UuidV4BatchContext(ctx context.Context, n int, formatted ...bool) ([]string, error) {
+	if n <= 0 {
+		return []string{}, nil
+	}
+	withHyphens := len(formatted) > 0 && formatted[0]
+	ids := make([]string, 0, n)
+	buf := make([]byte, 16*min(n, batchChunk))
+	for len(ids) < n {
+		if err := ctx.Err(); err != nil {
+			return ids, err
+		}
+		chunk := buf[:16*min(n-len(ids), batchChunk)]
+		if err := readRandom(chunk); err != nil {
+			return ids, err
+		}
+		for i := 0; i < len(chunk); i += 16 {
+			b := chunk[i : i+16]
+			setVersion(b, 4)
+			setVariantRFC4122(b)
+			ids = append(ids, bytesToUUIDString(b, withHyphens))
+		}
+	}
+	return ids, nil
+}
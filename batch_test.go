@@ -0,0 +1,196 @@
+package uid
+
+import (
+	"context"
+	"errors"
+	"io"
+	"testing"
+)
+
+func TestAssignV7(t *testing.T) {
+	values := make([]string, 1000)
+	dst := make([]*string, 0, len(values)+2)
+	for i := range values {
+		dst = append(dst, &values[i])
+		if i == 10 || i == 500 {
+			dst = append(dst, nil)
+		}
+	}
+
+	AssignV7(dst)
+
+	seen := make(map[string]bool, len(values))
+	for i, v := range values {
+		if v == "" {
+			t.Fatalf("value %d was not assigned", i)
+		}
+		assertLenAndVersion(t, v, 32, '7', false)
+		if seen[v] {
+			t.Fatalf("value %d is a duplicate: %s", i, v)
+		}
+		seen[v] = true
+		if i > 0 && values[i-1] >= v {
+			t.Fatalf("values not strictly increasing at %d: %s >= %s", i, values[i-1], v)
+		}
+	}
+}
+
+func TestAssignV7Formatted(t *testing.T) {
+	var a, b string
+	AssignV7([]*string{&a, &b}, true)
+	assertLenAndVersion(t, a, 36, '7', true)
+	assertLenAndVersion(t, b, 36, '7', true)
+	if a >= b {
+		t.Fatalf("values not strictly increasing: %s >= %s", a, b)
+	}
+}
+
+func TestIncrementV7_Carry(t *testing.T) {
+	b := []byte{0, 0, 0, 0, 0, 1, 0x7F, 0xFF, 0xBF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF}
+	incrementV7(b)
+	want := []byte{0, 0, 0, 0, 0, 2, 0x70, 0, 0x80, 0, 0, 0, 0, 0, 0, 0}
+	if string(b) != string(want) {
+		t.Fatalf("incrementV7 = %x, want %x", b, want)
+	}
+}
+
+func TestUniqueV4Set(t *testing.T) {
+	existing := make(map[string]bool)
+	for i := 0; i < 500; i++ {
+		existing[UuidV4()] = true
+		existing[UuidV4(true)] = true
+	}
+
+	ids := UniqueV4Set(1000, existing)
+	if len(ids) != 1000 {
+		t.Fatalf("UniqueV4Set returned %d IDs, want 1000", len(ids))
+	}
+	seen := make(map[string]bool, len(ids))
+	for _, id := range ids {
+		assertLenAndVersion(t, id, 32, '4', false)
+		if existing[id] {
+			t.Fatalf("UniqueV4Set returned existing ID %s", id)
+		}
+		if seen[id] {
+			t.Fatalf("UniqueV4Set returned duplicate ID %s", id)
+		}
+		seen[id] = true
+	}
+	if len(existing) != 1000 {
+		t.Fatalf("UniqueV4Set modified existing: %d entries", len(existing))
+	}
+
+	if got := UniqueV4Set(0, nil); len(got) != 0 {
+		t.Fatalf("UniqueV4Set(0) = %v, want empty", got)
+	}
+}
+
+func TestUuidV4Batch(t *testing.T) {
+	for _, n := range []int{-1, 0} {
+		if got := UuidV4Batch(n); got == nil || len(got) != 0 {
+			t.Fatalf("UuidV4Batch(%d) = %#v, want an empty slice", n, got)
+		}
+	}
+	ids := UuidV4Batch(1000)
+	if len(ids) != 1000 {
+		t.Fatalf("len = %d, want 1000", len(ids))
+	}
+	seen := make(map[string]bool, len(ids))
+	for _, id := range ids {
+		assertLenAndVersion(t, id, 32, '4', false)
+		if !IsValid(id) {
+			t.Fatalf("invalid UUID %q", id)
+		}
+		if seen[id] {
+			t.Fatalf("duplicate UUID %q", id)
+		}
+		seen[id] = true
+	}
+	for _, id := range UuidV4Batch(3, true) {
+		assertLenAndVersion(t, id, 36, '4', true)
+	}
+}
+
+func TestUuidV4Batch_FailingReader(t *testing.T) {
+	prev := Reader
+	Reader = failingReader{}
+	defer func() { Reader = prev }()
+	for _, id := range UuidV4Batch(3) {
+		assertLenAndVersion(t, id, 32, '4', false)
+	}
+}
+
+func BenchmarkUuidV4Loop(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		for j := 0; j < 1000; j++ {
+			_ = UuidV4()
+		}
+	}
+}
+
+func BenchmarkUuidV4Batch(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		_ = UuidV4Batch(1000)
+	}
+}
+
+// cancelingReader cancels a context after its first Read.
+type cancelingReader struct {
+	r      io.Reader
+	cancel context.CancelFunc
+}
+
+func (c cancelingReader) Read(p []byte) (int, error) {
+	defer c.cancel()
+	return c.r.Read(p)
+}
+
+func TestUuidV4BatchContext(t *testing.T) {
+	for _, n := range []int{-1, 0, 1, batchChunk, batchChunk + 1, 3000} {
+		ids, err := UuidV4BatchContext(context.Background(), n, n%2 == 0)
+		if err != nil {
+			t.Fatalf("UuidV4BatchContext(%d) error: %v", n, err)
+		}
+		if len(ids) != max(n, 0) {
+			t.Fatalf("UuidV4BatchContext(%d) returned %d IDs", n, len(ids))
+		}
+		seen := map[string]bool{}
+		for _, id := range ids {
+			if n%2 == 0 {
+				assertLenAndVersion(t, id, 36, '4', true)
+			} else {
+				assertLenAndVersion(t, id, 32, '4', false)
+			}
+			if seen[id] {
+				t.Fatalf("duplicate UUID %q", id)
+			}
+			seen[id] = true
+		}
+	}
+}
+
+func TestUuidV4BatchContext_Canceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	prev := Reader
+	Reader = cancelingReader{prev, cancel}
+	t.Cleanup(func() { Reader = prev })
+
+	ids, err := UuidV4BatchContext(ctx, 10*batchChunk)
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("error = %v, want context.Canceled", err)
+	}
+	if len(ids) != batchChunk {
+		t.Fatalf("got %d IDs, want the first chunk of %d", len(ids), batchChunk)
+	}
+}
+
+func TestUuidV4BatchContext_ReaderFails(t *testing.T) {
+	prev := Reader
+	Reader = failingReader{}
+	t.Cleanup(func() { Reader = prev })
+
+	if ids, err := UuidV4BatchContext(context.Background(), 10); err == nil || len(ids) != 0 {
+		t.Fatalf("got %d IDs, %v; want an error", len(ids), err)
+	}
+}
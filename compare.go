@@ -0,0 +1,103 @@
+package uid
+
+import (
+	"bytes"
+	"sort"
+	"strings"
+)
+
+// Equal reports whether a and b are valid UUIDs with the same 16 bytes,
+// regardless of their textual form or casing.
+//
+// Example: Equal("550E8400E29B41D4A716446655440000", "550e8400-e29b-41d4-a716-446655440000") => true
+//
+// Parameters:
+// - a, b: UUIDs in any form accepted by ParseWithFormat
+//
+// Returns:
+// - true if both parse and hold the same value; false otherwise
+func Equal(a, b string) bool {
+	ba, _, err := ParseWithFormat(a)
+	if err != nil {
+		return false
+	}
+	bb, _, err := ParseWithFormat(b)
+	if err != nil {
+		return false
+	}
+	return bytes.Equal(ba, bb)
+}
+
+// Compare orders two UUIDs by their 16 decoded bytes, which is time order
+// for v6 and v7. Unlike comparing the strings, it is correct for mixed
+// hyphenated, bare and upper/lowercase input.
+//
+// Invalid UUIDs sort after all valid ones; two invalid strings compare as
+// plain strings, so the order stays total and deterministic.
+//
+// Example: Compare("01890a5d-ac96-774b-bcce-b302099a8057", "01890A5DAC96774BBCCEB302099A8058") => -1
+//
+// Parameters:
+// - a, b: UUIDs in any form accepted by ParseWithFormat
+//
+// Returns:
+// - -1 if a sorts before b, 0 if they are equal, +1 otherwise
+func Compare(a, b string) int {
+	ba, _, errA := ParseWithFormat(a)
+	bb, _, errB := ParseWithFormat(b)
+	return compareParsed(ba, errA == nil, bb, errB == nil, a, b)
+}
+
+// compareParsed implements Compare on already decoded values; ok reports
+// whether the string parsed.
+func compareParsed(ba []byte, okA bool, bb []byte, okB bool, a, b string) int {
+	switch {
+	case okA && okB:
+		return bytes.Compare(ba, bb)
+	case okA:
+		return -1
+	case okB:
+		return 1
+	}
+	return strings.Compare(a, b)
+}
+
+// Less reports whether a sorts before b in the order of Compare. It suits
+// sort.Slice.
+//
+// Example: sort.Slice(ids, func(i, j int) bool { return uid.Less(ids[i], ids[j]) })
+//
+// Parameters:
+// - a, b: UUIDs in any form accepted by ParseWithFormat
+//
+// Returns:
+// - true if a sorts before b
+func Less(a, b string) bool {
+	return Compare(a, b) < 0
+}
+
+// Sort sorts uuids in place in the order of Compare, decoding each entry
+// once. Entries are only reordered, never rewritten, so mixed hyphenated
+// and bare input keeps its form. Invalid entries end up last, ordered as
+// plain strings.
+//
+// Parameters:
+// - uuids: the UUIDs to sort, in any form accepted by ParseWithFormat
+func Sort(uuids []string) {
+	type key struct {
+		b  []byte
+		ok bool
+		s  string
+	}
+	keys := make([]key, len(uuids))
+	for i, s := range uuids {
+		b, _, err := ParseWithFormat(s)
+		keys[i] = key{b, err == nil, s}
+	}
+	sort.SliceStable(keys, func(i, j int) bool {
+		return compareParsed(keys[i].b, keys[i].ok, keys[j].b, keys[j].ok, keys[i].s, keys[j].s) < 0
+	})
+	for i, k := range keys {
+		uuids[i] = k.s
+	}
+}
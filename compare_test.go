@@ -0,0 +1,85 @@
+package uid
+
+import (
+	"math/rand"
+	"sort"
+	"testing"
+)
+
+func TestEqual(t *testing.T) {
+	cases := []struct {
+		a, b string
+		want bool
+	}{
+		{"550e8400-e29b-41d4-a716-446655440000", "550E8400E29B41D4A716446655440000", true},
+		{"urn:uuid:550e8400-e29b-41d4-a716-446655440000", "{550E8400-E29B-41D4-A716-446655440000}", true},
+		{"550e8400-e29b-41d4-a716-446655440000", "550e8400-e29b-41d4-a716-446655440001", false},
+		{"invalid", "invalid", false},
+	}
+	for _, c := range cases {
+		if got := Equal(c.a, c.b); got != c.want {
+			t.Fatalf("Equal(%q, %q) = %v, want %v", c.a, c.b, got, c.want)
+		}
+	}
+}
+
+func TestCompare(t *testing.T) {
+	cases := []struct {
+		a, b string
+		want int
+	}{
+		{"01890a5d-ac96-774b-bcce-b302099a8057", "01890A5DAC96774BBCCEB302099A8058", -1},
+		{"01890a5dac96774bbcceb302099a8058", "01890a5d-ac96-774b-bcce-b302099a8057", 1},
+		{"{01890a5d-ac96-774b-bcce-b302099a8057}", "01890A5DAC96774BBCCEB302099A8057", 0},
+		// as strings the hyphen would sort before the 'a'
+		{"01890a5d-ffff-7fff-bfff-ffffffffffff", "01890a5dac96774bbcceb302099a8057", 1},
+		{"550e8400-e29b-41d4-a716-446655440000", "invalid", -1},
+		{"invalid", "550e8400-e29b-41d4-a716-446655440000", 1},
+		{"invalid-a", "invalid-b", -1},
+		{"invalid", "invalid", 0},
+	}
+	for _, c := range cases {
+		if got := Compare(c.a, c.b); got != c.want {
+			t.Fatalf("Compare(%q, %q) = %d, want %d", c.a, c.b, got, c.want)
+		}
+		if got := Less(c.a, c.b); got != (c.want < 0) {
+			t.Fatalf("Less(%q, %q) = %v", c.a, c.b, got)
+		}
+	}
+}
+
+func TestLess_V7GenerationOrder(t *testing.T) {
+	ids := make([]string, 1000)
+	for i := range ids {
+		ids[i] = UuidV7(i%2 == 0) // mix bare and hyphenated
+	}
+	shuffled := append([]string(nil), ids...)
+	rand.Shuffle(len(shuffled), func(i, j int) { shuffled[i], shuffled[j] = shuffled[j], shuffled[i] })
+	sort.Slice(shuffled, func(i, j int) bool { return Less(shuffled[i], shuffled[j]) })
+	for i := range ids {
+		if shuffled[i] != ids[i] {
+			t.Fatalf("position %d: got %q, want %q", i, shuffled[i], ids[i])
+		}
+	}
+}
+
+func TestSort(t *testing.T) {
+	v7s := make([]string, 5)
+	for i := range v7s {
+		v7s[i] = UuidV7(i%2 == 0)
+	}
+	nilBare := "00000000000000000000000000000000"
+	in := []string{"zz-invalid", v7s[3], NilUUID(), v7s[0], "", v7s[4], nilBare, v7s[2], v7s[1]}
+
+	Sort(in)
+
+	want := append([]string{NilUUID(), nilBare}, v7s...)
+	want = append(want, "", "zz-invalid")
+	for i := range want {
+		if in[i] != want[i] {
+			t.Fatalf("Sort position %d = %q, want %q (got %q)", i, in[i], want[i], in)
+		}
+	}
+
+	Sort(nil) // must not panic
+}
@@ -0,0 +1,206 @@
+package uid
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// Config declares the behavior of a Generator built with NewGenerator, so
+// applications embedding the package can wire it in one place instead of
+// calling several setters.
+type Config struct {
+	// Node is the 6-byte node ID of V1 and V6. When zero and RandomNode is
+	// false, the package node ID (a hardware address if available) is used.
+	Node [6]byte
+
+	// ClockSeq is the initial 14-bit clock sequence of V1 and V6. When
+	// zero, a random initial sequence is drawn, so independent generators
+	// do not start on the same sequence.
+	ClockSeq uint16
+
+	// RandomNode replaces Node with a random node ID (multicast bit set,
+	// per RFC 4122). It cannot be combined with a non-zero Node.
+	RandomNode bool
+
+	// DefaultCase is "lower" or "upper" for the hex digits of the
+	// generator's UUIDs. Empty follows the package default, see
+	// SetDefaultCase.
+	DefaultCase string
+
+	// UidStrategy is the collision-avoidance strategy of Uid, one of
+	// UidStrategySleep, UidStrategyMonotonic or UidStrategyRandom. Empty
+	// follows the package strategy, see SetUidStrategy.
+	UidStrategy string
+}
+
+// NewGenerator returns a Generator configured by cfg.
+//
+// Example: NewGenerator(Config{RandomNode: true, DefaultCase: "upper"})
+//
+// Parameters:
+// - cfg: the configuration, see Config
+//
+// Returns:
+// - The generator, or an error describing the first invalid field
+func NewGenerator(cfg Config) (*Generator, error) {
+	g := &Generator{}
+
+	if cfg.ClockSeq > 0x3FFF {
+		return nil, fmt.Errorf("clock sequence %d exceeds 14 bits", cfg.ClockSeq)
+	}
+	if cfg.ClockSeq != 0 {
+		g.clockSeq = cfg.ClockSeq
+		g.hasClockSeq = true
+	}
+
+	switch {
+	case cfg.RandomNode && cfg.Node != [6]byte{}:
+		return nil, errors.New("RandomNode cannot be combined with a non-zero Node")
+	case cfg.RandomNode:
+		if err := g.read(g.node[:]); err != nil {
+			return nil, err
+		}
+		g.node[0] |= 0x01 // multicast bit
+		g.hasNode = true
+	case cfg.Node != [6]byte{}:
+		g.node = cfg.Node
+		g.hasNode = true
+	}
+
+	switch cfg.DefaultCase {
+	case "":
+	case "lower":
+		g.hasCase = true
+	case "upper":
+		g.upper, g.hasCase = true, true
+	default:
+		return nil, fmt.Errorf("unknown case %q, expected \"lower\" or \"upper\"", cfg.DefaultCase)
+	}
+
+	switch cfg.UidStrategy {
+	case "", UidStrategySleep, UidStrategyMonotonic, UidStrategyRandom:
+		g.uidStrategy = cfg.UidStrategy
+	default:
+		return nil, fmt.Errorf("unknown Uid strategy %q", cfg.UidStrategy)
+	}
+
+	return g, nil
+}
+
+// V1 returns a version 1 UUID from the generator's node ID and clock
+// sequence.
+//
+// Parameters:
+// - formatted: when true, include hyphens
+//
+// Returns:
+// - The UUID v1 as a string, or an error
+func (g *Generator) V1(formatted ...bool) (string, error) {
+	t, cs, node, err := g.nextClock()
+	if err != nil {
+		return "", err
+	}
+	b := make([]byte, 16)
+	putV1(b, t, cs, node[:])
+	withHyphens := len(formatted) > 0 && formatted[0]
+	return g.encode(b, withHyphens), nil
+}
+
+// V6 returns a version 6 UUID from the generator's node ID and clock
+// sequence.
+//
+// Parameters:
+// - formatted: when true, include hyphens
+//
+// Returns:
+// - The UUID v6 as a string, or an error
+func (g *Generator) V6(formatted ...bool) (string, error) {
+	t, cs, node, err := g.nextClock()
+	if err != nil {
+		return "", err
+	}
+	b := make([]byte, 16)
+	putV6(b, t, cs, node[:])
+	withHyphens := len(formatted) > 0 && formatted[0]
+	return g.encode(b, withHyphens), nil
+}
+
+// uidKinds describes the time-prefixed IDs by the names used by
+// TimeResolution.
+var uidKinds = map[string]struct {
+	length int
+	pause  time.Duration
+	groups []int
+}{
+	"human": {humanUidLength, time.Nanosecond, []int{8, 4, 4, 16}},
+	"nano":  {nanoUidLength, time.Nanosecond, []int{8, 6, 6, 3}},
+	"micro": {microUidLength, time.Microsecond, []int{8, 6, 6}},
+	"sec":   {secUidLength, time.Second, []int{8, 6}},
+}
+
+// Uid returns a time-prefixed ID like HumanUid, NanoUid, MicroUid or
+// SecUid, using the generator's Uid strategy.
+//
+// Example: Uid("micro") => "20250831151133000012"
+//
+// Parameters:
+// - kind: "human", "nano", "micro" or "sec"
+// - formatted: when true, include hyphens as the matching function does
+//
+// Returns:
+// - The ID, or an error for an unknown kind
+func (g *Generator) Uid(kind string, formatted ...bool) (string, error) {
+	k, ok := uidKinds[kind]
+	if !ok {
+		return "", fmt.Errorf("unknown Uid kind %q", kind)
+	}
+	strategy := g.uidStrategy
+	if strategy == "" {
+		uidMu.Lock()
+		strategy = uidStrategy
+		uidMu.Unlock()
+	}
+	s := newUidWith(strategy, k.length, k.pause)
+	withHyphens := len(formatted) > 0 && formatted[0]
+	if withHyphens {
+		return formatWithHyphens(s, k.groups), nil
+	}
+	return s, nil
+}
+
+// nextClock returns the generator's current Gregorian timestamp, clock
+// sequence and node ID, bumping the sequence when the clock has not
+// advanced.
+func (g *Generator) nextClock() (uint64, uint16, [6]byte, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if !g.hasClockSeq {
+		var r [2]byte
+		if err := g.read(r[:]); err != nil {
+			return 0, 0, [6]byte{}, err
+		}
+		g.clockSeq = binary.BigEndian.Uint16(r[:]) & 0x3FFF
+		g.hasClockSeq = true
+	}
+	node := g.nodeIDLocked()
+	t, err := gregorian100ns(g.clock())
+	if err != nil {
+		return 0, 0, [6]byte{}, err
+	}
+	if t <= g.lastTime {
+		g.clockSeq = (g.clockSeq + 1) & 0x3FFF
+	}
+	g.lastTime = t
+	return t, g.clockSeq, node, nil
+}
+
+// nodeIDLocked returns the node ID of V1, V6 and V6At: the configured one,
+// else the package node ID. g.mu must be held.
+func (g *Generator) nodeIDLocked() [6]byte {
+	if g.hasNode {
+		return g.node
+	}
+	return currentNodeID()
+}
@@ -0,0 +1,143 @@
+package uid
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestNewGenerator_Node(t *testing.T) {
+	g, err := NewGenerator(Config{Node: [6]byte{0xAA, 0xBB, 0xCC, 0xDD, 0xEE, 0xFF}, ClockSeq: 0x1234})
+	if err != nil {
+		t.Fatalf("NewGenerator error: %v", err)
+	}
+	for _, gen := range []func(...bool) (string, error){g.V1, g.V6} {
+		s, err := gen(true)
+		if err != nil {
+			t.Fatalf("generate error: %v", err)
+		}
+		if !strings.HasSuffix(s, "-aabbccddeeff") {
+			t.Fatalf("%q does not end with the configured node", s)
+		}
+	}
+	at, err := g.V6At(time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC), true)
+	if err != nil {
+		t.Fatalf("V6At error: %v", err)
+	}
+	if !strings.HasSuffix(at, "-aabbccddeeff") {
+		t.Fatalf("V6At %q does not end with the configured node", at)
+	}
+	v1, _ := g.V1(true)
+	assertLenAndVersion(t, v1, 36, '1', true)
+	v6, _ := g.V6(true)
+	assertLenAndVersion(t, v6, 36, '6', true)
+}
+
+func TestNewGenerator_ClockSeq(t *testing.T) {
+	g, err := NewGenerator(Config{ClockSeq: 0x1234})
+	if err != nil {
+		t.Fatalf("NewGenerator error: %v", err)
+	}
+	frozen := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	g.now = func() time.Time { return frozen }
+	a, _ := g.V1()
+	b, _ := g.V1()
+	if got := a[16:20]; got != "9234" {
+		t.Fatalf("clock seq field = %q, want %q", got, "9234")
+	}
+	if got := b[16:20]; got != "9235" {
+		t.Fatalf("clock seq field after frozen clock = %q, want %q", got, "9235")
+	}
+}
+
+func TestNewGenerator_RandomClockSeq(t *testing.T) {
+	// two generators draw the same sequence 1 time in 16384; requiring
+	// three to agree makes a false failure practically impossible
+	seqs := make(map[string]bool)
+	for i := 0; i < 3; i++ {
+		g, err := NewGenerator(Config{})
+		if err != nil {
+			t.Fatalf("NewGenerator error: %v", err)
+		}
+		s, _ := g.V1()
+		seqs[s[16:20]] = true
+	}
+	if len(seqs) == 1 {
+		t.Fatalf("zero-config generators share clock sequence %v", seqs)
+	}
+}
+
+func TestNewGenerator_RandomNode(t *testing.T) {
+	g, err := NewGenerator(Config{RandomNode: true})
+	if err != nil {
+		t.Fatalf("NewGenerator error: %v", err)
+	}
+	s, _ := g.V1()
+	b, _, _ := ParseWithFormat(s)
+	if b[10]&0x01 == 0 {
+		t.Fatalf("random node %x does not have the multicast bit set", b[10:])
+	}
+}
+
+func TestNewGenerator_Case(t *testing.T) {
+	upper, err := NewGenerator(Config{DefaultCase: "upper"})
+	if err != nil {
+		t.Fatalf("NewGenerator error: %v", err)
+	}
+	lower, err := NewGenerator(Config{DefaultCase: "lower"})
+	if err != nil {
+		t.Fatalf("NewGenerator error: %v", err)
+	}
+	SetDefaultCase(true)
+	defer SetDefaultCase(false)
+
+	s, _ := upper.V4(true)
+	if s != strings.ToUpper(s) {
+		t.Fatalf("upper generator produced %q", s)
+	}
+	s, _ = lower.V7(true)
+	if s != strings.ToLower(s) {
+		t.Fatalf("lower generator ignored its config under an uppercase package default: %q", s)
+	}
+}
+
+func TestNewGenerator_UidStrategy(t *testing.T) {
+	useSteppingClock(t, 0)
+	g, err := NewGenerator(Config{UidStrategy: UidStrategyMonotonic})
+	if err != nil {
+		t.Fatalf("NewGenerator error: %v", err)
+	}
+	prev := ""
+	for i := 0; i < 100; i++ {
+		s, err := g.Uid("micro")
+		if err != nil {
+			t.Fatalf("Uid error: %v", err)
+		}
+		if len(s) != microUidLength {
+			t.Fatalf("len(%q) = %d, want %d", s, len(s), microUidLength)
+		}
+		if s <= prev {
+			t.Fatalf("monotonic generator produced %q after %q", s, prev)
+		}
+		prev = s
+	}
+	s, _ := g.Uid("sec", true)
+	assertHyphenPositions(t, s, 15, []int{8})
+	if _, err := g.Uid("minute"); err == nil {
+		t.Fatal("expected error for unknown kind")
+	}
+}
+
+func TestNewGenerator_Invalid(t *testing.T) {
+	cases := []Config{
+		{ClockSeq: 0x4000},
+		{RandomNode: true, Node: [6]byte{1}},
+		{DefaultCase: "title"},
+		{UidStrategy: "fast"},
+	}
+	for _, cfg := range cases {
+		if _, err := NewGenerator(cfg); err == nil {
+			t.Fatalf("NewGenerator(%+v) expected error", cfg)
+		}
+	}
+}
@@ -0,0 +1,142 @@
+package uid
+
+import "fmt"
+
+// IsNumericUid reports whether s looks like one of the time-prefixed
+// numeric IDs (SecUid, MicroUid, NanoUid or HumanUid): all digits with a
+// length of 14, 20, 23 or 32. Formatted (hyphenated) IDs are not accepted.
+//
+// Parameters:
+// - s: the candidate ID
+//
+// Returns:
+// - true if s has the shape of a numeric Uid
+func IsNumericUid(s string) bool {
+	switch len(s) {
+	case secUidLength, microUidLength, nanoUidLength, humanUidLength:
+	default:
+		return false
+	}
+	for i := 0; i < len(s); i++ {
+		if s[i] < '0' || s[i] > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// IsUUID reports whether s is a UUID in any form accepted by
+// ParseWithFormat (bare, hyphenated, URN or braced).
+//
+// Note that a 32-digit HumanUid is also valid hexadecimal, so it is
+// reported as a UUID too; check IsNumericUid first when both can occur.
+//
+// Parameters:
+// - s: the candidate ID
+//
+// Returns:
+// - true if s parses as a UUID
+func IsUUID(s string) bool {
+	_, _, err := ParseWithFormat(s)
+	return err == nil
+}
+
+// Encodings reported by DetectEncoding.
+const (
+	EncodingUUIDHyphenated = "uuid-hyphenated"
+	EncodingUUIDBare       = "uuid-bare"
+	EncodingBase62         = "base62"
+	EncodingBase32         = "base32"
+	EncodingNumeric        = "numeric"
+	EncodingUnknown        = "unknown"
+)
+
+// DetectEncoding guesses how an ID string is encoded from its length and
+// character set. The rules are applied in this order:
+//
+//  1. 36 characters in the 8-4-4-4-12 hex layout: "uuid-hyphenated"
+//  2. all digits with a numeric Uid length (14, 20, 23 or 32, see
+//     IsNumericUid): "numeric". A 32-digit string is therefore reported as a
+//     HumanUid rather than a bare UUID; a random v4 UUID is all digits
+//     with a probability of about 1 in 2.7 million ((10/16)^30 for its 30
+//     free hex digits, times 1/2 for a variant digit of 8 or 9).
+//  3. 32 hex characters: "uuid-bare"
+//  4. 26 Crockford Base32 characters decoding to at most 128 bits: "base32"
+//  5. 22 Base62 characters decoding to at most 128 bits: "base62"
+//  6. anything else, including URN and braced UUIDs: "unknown"
+//
+// The lengths of the last three rules are distinct, so no further
+// disambiguation is needed.
+//
+// Parameters:
+// - s: the candidate ID
+//
+// Returns:
+// - One of the Encoding constants
+func DetectEncoding(s string) string {
+	switch {
+	case len(s) == 36 && decodeUUIDCoreOK(s):
+		return EncodingUUIDHyphenated
+	case IsNumericUid(s):
+		return EncodingNumeric
+	case len(s) == 32 && decodeUUIDCoreOK(s):
+		return EncodingUUIDBare
+	case len(s) == crockfordLength:
+		if _, err := decodeCrockford(s); err == nil {
+			return EncodingBase32
+		}
+	case len(s) == base62Length:
+		if _, err := decodeBase(s, len(base62Alphabet), base62Digit, 16); err == nil {
+			return EncodingBase62
+		}
+	}
+	return EncodingUnknown
+}
+
+// decodeUUIDCoreOK reports whether s is a bare or hyphenated UUID.
+func decodeUUIDCoreOK(s string) bool {
+	_, err := decodeUUIDCore(s)
+	return err == nil
+}
+
+// Decode converts an ID in any supported UUID encoding into the canonical
+// hyphenated UUID. It is the single entry point for columns that mix
+// encodings.
+//
+// Accepted forms, in order of precedence:
+//
+//  1. URN, braced and hyphenated UUIDs (unambiguous by their decoration)
+//  2. bare 32-character hex UUIDs, unless all digits: a 32-digit string is
+//     also a valid HumanUid, so it is rejected as ambiguous
+//  3. 26-character Crockford Base32 (see ToBase32)
+//  4. 22-character Base62 (see ToBase62)
+//
+// Numeric Uids of other lengths are not UUIDs and are rejected, as is any
+// other input.
+//
+// Example: Decode("2aUyqjCzEIiEcYMKj7TZtw") => "550e8400-e29b-41d4-a716-446655440000"
+//
+// Parameters:
+// - s: the encoded ID
+//
+// Returns:
+// - The lowercase hyphenated UUID
+// - An error if s is ambiguous or not a recognized encoding
+func Decode(s string) (string, error) {
+	switch DetectEncoding(s) {
+	case EncodingNumeric:
+		if len(s) == 32 {
+			return "", fmt.Errorf("ambiguous ID %q: both a HumanUid and a bare UUID", s)
+		}
+		return "", fmt.Errorf("numeric Uid %q is not a UUID", s)
+	case EncodingBase32:
+		return ParseBase32(s)
+	case EncodingBase62:
+		return FromBase62(s)
+	}
+	b, _, err := ParseWithFormat(s)
+	if err != nil {
+		return "", fmt.Errorf("unrecognized ID encoding %q", s)
+	}
+	return canonicalString(b), nil
+}
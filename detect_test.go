@@ -0,0 +1,138 @@
+package uid
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestIsNumericUid(t *testing.T) {
+	numeric := []string{
+		"20250831151133",
+		"20250831151133000012",
+		"20250831151133000012345",
+		"20250831151133000012345678901234",
+	}
+	for _, s := range numeric {
+		if !IsNumericUid(s) {
+			t.Fatalf("IsNumericUid(%q) = false, want true", s)
+		}
+	}
+
+	others := []string{
+		"",
+		"2025083115113",
+		"20171119-084926",
+		"2025083115113300001a",
+		"550e8400e29b41d4a716446655440000",
+		"550e8400-e29b-41d4-a716-446655440000",
+	}
+	for _, s := range others {
+		if IsNumericUid(s) {
+			t.Fatalf("IsNumericUid(%q) = true, want false", s)
+		}
+	}
+}
+
+func TestIsUUID(t *testing.T) {
+	uuids := []string{
+		UuidV4(),
+		UuidV7(true),
+		"urn:uuid:550e8400-e29b-41d4-a716-446655440000",
+		"{550e8400-e29b-41d4-a716-446655440000}",
+	}
+	for _, s := range uuids {
+		if !IsUUID(s) {
+			t.Fatalf("IsUUID(%q) = false, want true", s)
+		}
+		if IsNumericUid(s) {
+			t.Fatalf("IsNumericUid(%q) = true for a UUID", s)
+		}
+	}
+
+	for _, s := range []string{"", "20250831151133", "20250831151133000012", "not-a-uuid"} {
+		if IsUUID(s) {
+			t.Fatalf("IsUUID(%q) = true, want false", s)
+		}
+	}
+}
+
+func TestDetectEncoding(t *testing.T) {
+	cases := map[string]string{
+		"550e8400-e29b-41d4-a716-446655440000":      EncodingUUIDHyphenated,
+		"550e8400e29b41d4a716446655440000":          EncodingUUIDBare,
+		"550E8400E29B41D4A716446655440000":          EncodingUUIDBare,
+		"2aUyqjCzEIiEcYMKj7TZtw":                    EncodingBase62,
+		"2N1T201RMV87AAE5J4CSAM8000":                EncodingBase32,
+		"20250831151133000012":                      EncodingNumeric,
+		"20250831151133000012345678901234":          EncodingNumeric,
+		"":                                          EncodingUnknown,
+		"hello":                                     EncodingUnknown,
+		"{550e8400-e29b-41d4-a716-446655440000}":    EncodingUnknown,
+		"550e8400-e29b-41d4-a716-44665544000g":      EncodingUnknown,
+		"ZZZZZZZZZZZZZZZZZZZZZZZZZZ":                EncodingUnknown, // exceeds 128 bits
+		"2aUyqjCzEIiEcYMKj7TZt!":                    EncodingUnknown,
+		"urn:uuid:550e8400-e29b-41d4-a716-44665544": EncodingUnknown,
+	}
+	for in, want := range cases {
+		if got := DetectEncoding(in); got != want {
+			t.Fatalf("DetectEncoding(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestDetectEncoding_Generated(t *testing.T) {
+	id := UuidV7(true)
+	b62, _ := ToBase62(id)
+	b32, _ := ToBase32(id)
+	cases := map[string]string{
+		id:        EncodingUUIDHyphenated,
+		UuidV7():  EncodingUUIDBare,
+		b62:       EncodingBase62,
+		b32:       EncodingBase32,
+		SecUid():  EncodingNumeric,
+		NanoUid(): EncodingNumeric,
+	}
+	for in, want := range cases {
+		if got := DetectEncoding(in); got != want {
+			t.Fatalf("DetectEncoding(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestDecode(t *testing.T) {
+	const want = "550e8400-e29b-41d4-a716-446655440000"
+	for _, in := range []string{
+		want,
+		"550E8400E29B41D4A716446655440000",
+		"urn:uuid:550e8400-e29b-41d4-a716-446655440000",
+		"{550e8400-e29b-41d4-a716-446655440000}",
+		"2aUyqjCzEIiEcYMKj7TZtw",
+		"2N1T201RMV87AAE5J4CSAM8000",
+		"2n1t201rmv87aae5j4csam8000",
+	} {
+		got, err := Decode(in)
+		if err != nil {
+			t.Fatalf("Decode(%q) error: %v", in, err)
+		}
+		if got != want {
+			t.Fatalf("Decode(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestDecode_Rejects(t *testing.T) {
+	for _, in := range []string{
+		"12345678901234567890123456789012", // HumanUid or bare UUID: ambiguous
+		"20250831151133",                   // SecUid
+		"",
+		"hello",
+		"ZZZZZZZZZZZZZZZZZZZZZZZZZZ",
+	} {
+		if got, err := Decode(in); err == nil {
+			t.Fatalf("Decode(%q) = %q, expected error", in, got)
+		}
+	}
+	if _, err := Decode("12345678901234567890123456789012"); err == nil || !strings.Contains(err.Error(), "ambiguous") {
+		t.Fatalf("expected ambiguity error, got %v", err)
+	}
+}
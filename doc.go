@@ -0,0 +1,17 @@
+// Package uid generates and parses unique identifiers: RFC 9562 UUIDs
+// (versions 1 to 8), ULIDs and time-prefixed numeric IDs.
+//
+// # Concurrency
+//
+// Every generator and parser is safe for concurrent use. Shared state (the
+// v1/v6 clock sequence and node ID, the v7 and ULID monotonic sequences and
+// the last time-prefixed IDs) is guarded by mutexes, so concurrent callers
+// never receive the same v7, ULID or (except under UidStrategyRandom)
+// time-prefixed ID from one process. The same holds for v1 and v6 until
+// the 14-bit clock sequence wraps within one clock tick; after that a
+// sequence value can be reused and duplicates become possible, which
+// ClockSeqWraps lets you monitor. The setters (SetNodeID, SetRandomNodeID,
+// SetDefaultCase, SetUidStrategy) may also be called concurrently with
+// generation. The only exceptions are the Reader variable and SetClock,
+// which must be set before generating concurrently.
+package uid
@@ -0,0 +1,79 @@
+package uid
+
+import (
+	"sync"
+	"testing"
+)
+
+// TestConcurrentGenerators runs every stateful generator from many
+// goroutines; run with -race to check the locking.
+func TestConcurrentGenerators(t *testing.T) {
+	const goroutines, perGoroutine = 16, 2000
+	generators := map[string]func() string{
+		"v1":   func() string { return UuidV1() },
+		"v4":   func() string { return UuidV4() },
+		"v6":   func() string { return UuidV6() },
+		"v7":   func() string { return UuidV7() },
+		"ulid": Ulid,
+		"nano": func() string { return NanoUidFast() },
+	}
+	for name, gen := range generators {
+		t.Run(name, func(t *testing.T) {
+			results := make([][]string, goroutines)
+			var wg sync.WaitGroup
+			for g := range results {
+				wg.Add(1)
+				go func(g int) {
+					defer wg.Done()
+					ids := make([]string, perGoroutine)
+					for i := range ids {
+						ids[i] = gen()
+					}
+					results[g] = ids
+				}(g)
+			}
+			wg.Wait()
+
+			seen := make(map[string]bool, goroutines*perGoroutine)
+			for _, ids := range results {
+				for _, id := range ids {
+					if seen[id] {
+						t.Fatalf("duplicate %s %q", name, id)
+					}
+					seen[id] = true
+				}
+			}
+		})
+	}
+}
+
+// TestConcurrentSetters changes the package settings while generating.
+func TestConcurrentSetters(t *testing.T) {
+	prevNode := currentNodeID()
+	t.Cleanup(func() {
+		SetNodeID(prevNode[:])
+		SetDefaultCase(false)
+	})
+
+	var wg sync.WaitGroup
+	for g := 0; g < 8; g++ {
+		wg.Add(2)
+		go func(g int) {
+			defer wg.Done()
+			for i := 0; i < 200; i++ {
+				UuidV1()
+				UuidV6()
+				NodeID()
+				ClockSequence()
+			}
+		}(g)
+		go func(g int) {
+			defer wg.Done()
+			for i := 0; i < 50; i++ {
+				SetNodeID([]byte{0x02, 0, 0, 0, byte(g), byte(i)})
+				SetDefaultCase(i%2 == 0)
+			}
+		}(g)
+	}
+	wg.Wait()
+}
@@ -0,0 +1,374 @@
+package uid
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+// crockfordAlphabet is Crockford's Base32 alphabet. It excludes I, L, O and
+// U to avoid transcription mistakes.
+const crockfordAlphabet = "0123456789ABCDEFGHJKMNPQRSTVWXYZ"
+
+// crockfordLength is the number of Base32 characters needed for 128 bits.
+const crockfordLength = 26
+
+// encodeBase encodes b as a big-endian number in the given alphabet,
+// left-padded with the zero digit to width characters.
+func encodeBase(b []byte, alphabet string, width int) string {
+	n := new(big.Int).SetBytes(b)
+	base := big.NewInt(int64(len(alphabet)))
+	mod := new(big.Int)
+	out := make([]byte, width)
+	for i := width - 1; i >= 0; i-- {
+		n.DivMod(n, base, mod)
+		out[i] = alphabet[mod.Int64()]
+	}
+	return string(out)
+}
+
+// decodeBase decodes s as a big-endian number whose digits are mapped by
+// digit (returning -1 for characters outside the alphabet). The value must
+// fit in size bytes.
+func decodeBase(s string, base int, digit func(c byte) int, size int) ([]byte, error) {
+	if s == "" {
+		return nil, errors.New("empty input")
+	}
+	n := new(big.Int)
+	bigBase := big.NewInt(int64(base))
+	for i := 0; i < len(s); i++ {
+		d := digit(s[i])
+		if d < 0 {
+			return nil, fmt.Errorf("invalid character %q at index %d", s[i], i)
+		}
+		n.Mul(n, bigBase)
+		n.Add(n, big.NewInt(int64(d)))
+	}
+	if n.BitLen() > size*8 {
+		return nil, fmt.Errorf("value exceeds %d bytes", size)
+	}
+	return n.FillBytes(make([]byte, size)), nil
+}
+
+// crockfordDigit maps a Crockford Base32 character to its value. Decoding
+// is case-insensitive and accepts the I/L (1) and O (0) aliases.
+func crockfordDigit(c byte) int {
+	switch c {
+	case 'i', 'I', 'l', 'L':
+		return 1
+	case 'o', 'O':
+		return 0
+	}
+	return strings.IndexByte(crockfordAlphabet, upperASCII(c))
+}
+
+// encodeCrockford encodes 16 bytes as 26 uppercase Crockford Base32
+// characters.
+func encodeCrockford(b []byte) string {
+	return encodeBase(b, crockfordAlphabet, crockfordLength)
+}
+
+// decodeCrockford decodes a 26-character Crockford Base32 string into 16
+// bytes.
+func decodeCrockford(s string) ([]byte, error) {
+	if len(s) != crockfordLength {
+		return nil, fmt.Errorf("invalid Base32 length %d, want %d", len(s), crockfordLength)
+	}
+	return decodeBase(s, 32, crockfordDigit, 16)
+}
+
+func upperASCII(c byte) byte {
+	if c >= 'a' && c <= 'z' {
+		return c - 'a' + 'A'
+	}
+	return c
+}
+
+// base62Alphabet is the digits-then-uppercase-then-lowercase Base62
+// alphabet.
+const base62Alphabet = "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz"
+
+// base62Length is the number of Base62 characters needed for 128 bits.
+const base62Length = 22
+
+// ToBase62 encodes a UUID as a fixed-width 22-character Base62 string.
+//
+// Example: 550e8400-e29b-41d4-a716-446655440000 => 2aUyqjCzEIiEcYMKj7TZtw (length: 22)
+//
+// Parameters:
+// - s: a UUID in any form accepted by ParseWithFormat
+//
+// Returns:
+// - The Base62 code, or an error if s is invalid
+func ToBase62(s string) (string, error) {
+	b, _, err := ParseWithFormat(s)
+	if err != nil {
+		return "", err
+	}
+	return encodeBase(b, base62Alphabet, base62Length), nil
+}
+
+// FromBase62 decodes a Base62 code into the canonical hyphenated UUID.
+// Codes shorter than 22 characters are treated as having leading zeros.
+//
+// Example: 2aUyqjCzEIiEcYMKj7TZtw => 550e8400-e29b-41d4-a716-446655440000
+//
+// Parameters:
+// - code: the Base62 code
+//
+// Returns:
+// - The canonical UUID, or an error if code has invalid characters or
+// decodes to more than 16 bytes
+func FromBase62(code string) (string, error) {
+	b, err := decodeBase(code, len(base62Alphabet), base62Digit, 16)
+	if err != nil {
+		return "", fmt.Errorf("invalid Base62 UUID %q: %w", code, err)
+	}
+	return canonicalString(b), nil
+}
+
+// FromBase62All decodes a batch of Base62 codes, preserving order.
+//
+// Parameters:
+// - codes: the Base62 codes
+//
+// Returns:
+// - The canonical UUIDs; entries that failed to decode are empty
+// - A parallel slice holding the error of each failed entry, nil otherwise
+func FromBase62All(codes []string) ([]string, []error) {
+	uuids := make([]string, len(codes))
+	errs := make([]error, len(codes))
+	for i, code := range codes {
+		uuids[i], errs[i] = FromBase62(code)
+	}
+	return uuids, errs
+}
+
+func base62Digit(c byte) int {
+	return strings.IndexByte(base62Alphabet, c)
+}
+
+// base58Alphabet is the Bitcoin Base58 alphabet: Base62 without 0, O, I
+// and l.
+const base58Alphabet = "123456789ABCDEFGHJKLMNPQRSTUVWXYZabcdefghijkmnopqrstuvwxyz"
+
+// base58Length is the number of Base58 characters needed for 128 bits.
+const base58Length = 22
+
+// UuidBase58 returns a random (version 4) UUID as a fixed-width
+// 22-character Base58 string (Bitcoin alphabet, left-padded with '1', the
+// zero digit). It is URL-safe and avoids 0/O and I/l confusion. Decode
+// with ParseBase58.
+//
+// Example: BWBeN28Vb7cMEx7Ym8AUzs (length: 22)
+func UuidBase58() string {
+	return encodeBase(newV4(), base58Alphabet, base58Length)
+}
+
+// ParseBase58 decodes a Base58 string into the canonical hyphenated UUID.
+// Codes shorter than 22 characters are treated as having leading zeros.
+//
+// Example: BWBeN28Vb7cMEx7Ym8AUzs => 550e8400-e29b-41d4-a716-446655440000
+//
+// Parameters:
+// - code: the Base58 string
+//
+// Returns:
+// - The canonical UUID, or an error if code has characters outside the
+// alphabet or decodes to more than 16 bytes
+func ParseBase58(code string) (string, error) {
+	b, err := decodeBase(code, len(base58Alphabet), base58Digit, 16)
+	if err != nil {
+		return "", fmt.Errorf("invalid Base58 UUID %q: %w", code, err)
+	}
+	return canonicalString(b), nil
+}
+
+func base58Digit(c byte) int {
+	return strings.IndexByte(base58Alphabet, c)
+}
+
+// UuidBase64 returns a random (version 4) UUID as 22 characters of
+// unpadded URL-safe Base64, compact enough for cookies and query
+// parameters. Decode with ParseBase64.
+//
+// Example: VQ6EAOKbQdSnFkRmVUQAAA (length: 22)
+func UuidBase64() string {
+	return base64.RawURLEncoding.EncodeToString(newV4())
+}
+
+// ParseBase64 decodes an unpadded URL-safe Base64 string into the
+// canonical hyphenated UUID. The input must decode to exactly 16 bytes, and
+// its unused trailing bits must be zero so that every UUID has exactly one
+// encoding.
+//
+// Example: VQ6EAOKbQdSnFkRmVUQAAA => 550e8400-e29b-41d4-a716-446655440000
+//
+// Parameters:
+// - code: the Base64 string
+//
+// Returns:
+// - The canonical UUID, or an error if code is invalid
+func ParseBase64(code string) (string, error) {
+	b, err := base64.RawURLEncoding.Strict().DecodeString(code)
+	if err != nil {
+		return "", fmt.Errorf("invalid Base64 UUID %q: %w", code, err)
+	}
+	if len(b) != 16 {
+		return "", fmt.Errorf("invalid Base64 UUID %q: decodes to %d bytes, want 16", code, len(b))
+	}
+	return canonicalString(b), nil
+}
+
+// base57Alphabet is the Base57 alphabet of the popular shortuuid
+// libraries: digits and letters without 0, 1, I, O and l.
+const base57Alphabet = "23456789ABCDEFGHJKLMNPQRSTUVWXYZabcdefghijkmnopqrstuvwxyz"
+
+// shortLength is the number of Base57 characters needed for 128 bits.
+const shortLength = 22
+
+// UuidShort returns a random (version 4) UUID as a fixed-width
+// 22-character Base57 string, a compact URL-safe form that is still a full
+// 128-bit UUID. Expand it with UuidShortToCanonical.
+//
+// Example: H9cNmGXLEc8NWcZzSThA9S (length: 22)
+func UuidShort() string {
+	return encodeBase(newV4(), base57Alphabet, shortLength)
+}
+
+// UuidShortToCanonical expands a code produced by UuidShort into the
+// canonical hyphenated UUID.
+//
+// Example: H9cNmGXLEc8NWcZzSThA9S => 550e8400-e29b-41d4-a716-446655440000
+//
+// Parameters:
+// - s: the 22-character Base57 code
+//
+// Returns:
+// - The canonical UUID, or an error if s has the wrong length, characters
+// outside the alphabet or decodes to more than 16 bytes
+func UuidShortToCanonical(s string) (string, error) {
+	if len(s) != shortLength {
+		return "", fmt.Errorf("invalid short UUID %q: length %d, want %d", s, len(s), shortLength)
+	}
+	b, err := decodeBase(s, len(base57Alphabet), base57Digit, 16)
+	if err != nil {
+		return "", fmt.Errorf("invalid short UUID %q: %w", s, err)
+	}
+	return canonicalString(b), nil
+}
+
+func base57Digit(c byte) int {
+	return strings.IndexByte(base57Alphabet, c)
+}
+
+// ToBase32 encodes a UUID as 26 uppercase Crockford Base32 characters.
+//
+// 128 bits need 26 Base32 characters (26 × 5 = 130 bits); any shorter form
+// such as a 20-character column cannot hold a UUID without loss. Use
+// ToBase32Truncated only if the resulting collision risk is acceptable.
+//
+// Example: 550e8400-e29b-41d4-a716-446655440000 => 2N1T201RMV87AAE5J4CSAM8000 (length: 26)
+//
+// Parameters:
+// - s: a UUID in any form accepted by ParseWithFormat
+//
+// Returns:
+// - The Base32 string, or an error if s is invalid
+func ToBase32(s string) (string, error) {
+	b, _, err := ParseWithFormat(s)
+	if err != nil {
+		return "", err
+	}
+	return encodeCrockford(b), nil
+}
+
+// ToBase32Truncated returns the first n characters of ToBase32(s).
+//
+// This is LOSSY: the dropped characters cannot be recovered, and distinct
+// UUIDs may share a truncated form. With n characters only 5n bits remain
+// (for a v7, the leading characters hold the timestamp, so truncation drops
+// randomness first). There is no inverse function.
+//
+// Parameters:
+// - s: a UUID in any form accepted by ParseWithFormat
+// - n: the number of characters to keep, 1 to 26
+//
+// Returns:
+// - The truncated Base32 string, or an error if s or n is invalid
+func ToBase32Truncated(s string, n int) (string, error) {
+	if n < 1 || n > crockfordLength {
+		return "", fmt.Errorf("truncation length must be between 1 and %d", crockfordLength)
+	}
+	full, err := ToBase32(s)
+	if err != nil {
+		return "", err
+	}
+	return full[:n], nil
+}
+
+// ParseBase32 decodes a 26-character Crockford Base32 string into the
+// canonical hyphenated UUID. Decoding is case-insensitive and accepts the
+// I/L and O aliases of 1 and 0.
+//
+// Example: 2N1T201RMV87AAE5J4CSAM8000 => 550e8400-e29b-41d4-a716-446655440000
+//
+// Parameters:
+// - code: the Base32 string
+//
+// Returns:
+// - The canonical UUID, or an error if code is invalid
+func ParseBase32(code string) (string, error) {
+	b, err := decodeCrockford(code)
+	if err != nil {
+		return "", fmt.Errorf("invalid Base32 UUID %q: %w", code, err)
+	}
+	return canonicalString(b), nil
+}
+
+// UuidBase32 returns a random (version 4) UUID as 26 uppercase Crockford
+// Base32 characters, a shorter, case-insensitive form for user-facing IDs.
+// The alphabet has no I, L, O or U, which avoids transcription mistakes.
+// Decode with ParseBase32.
+//
+// Example: 2N1T201RMV87AAE5J4CSAM8000 (length: 26)
+func UuidBase32() string {
+	return encodeCrockford(newV4())
+}
+
+// ToQRCode encodes a UUID as the 26-character uppercase Crockford Base32
+// string, the shortest form suited to QR codes for device pairing.
+//
+// Uppercase matters: QR alphanumeric mode covers only digits, uppercase
+// letters and a few symbols, at 5.5 bits per character. A single lowercase
+// letter forces byte mode at 8 bits per character. The 26 characters fit
+// in 143 bits in alphanumeric mode, against 288 bits for a lowercase
+// hyphenated UUID in byte mode, which allows a smaller, more robust code.
+//
+// Example: 550e8400-e29b-41d4-a716-446655440000 => 2N1T201RMV87AAE5J4CSAM8000 (length: 26)
+//
+// Parameters:
+// - s: a UUID in any form accepted by ParseWithFormat
+//
+// Returns:
+// - The QR payload, or an error if s is invalid
+func ToQRCode(s string) (string, error) {
+	return ToBase32(s)
+}
+
+// FromQRCode decodes a payload produced by ToQRCode into the canonical
+// hyphenated UUID. Like ParseBase32 it is case-insensitive and accepts the
+// I/L and O aliases, since scanned text may have been retyped.
+//
+// Example: 2N1T201RMV87AAE5J4CSAM8000 => 550e8400-e29b-41d4-a716-446655440000
+//
+// Parameters:
+// - code: the scanned QR payload
+//
+// Returns:
+// - The canonical UUID, or an error if code is not 26 Base32 characters
+func FromQRCode(code string) (string, error) {
+	return ParseBase32(code)
+}
@@ -0,0 +1,347 @@
+package uid
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestCrockfordRoundTrip(t *testing.T) {
+	for _, b := range [][]byte{
+		make([]byte, 16),
+		bytes.Repeat([]byte{0xFF}, 16),
+		newV4(),
+		newV7(),
+	} {
+		s := encodeCrockford(b)
+		if len(s) != crockfordLength {
+			t.Fatalf("encodeCrockford length = %d, want %d", len(s), crockfordLength)
+		}
+		got, err := decodeCrockford(s)
+		if err != nil {
+			t.Fatalf("decodeCrockford(%s) error: %v", s, err)
+		}
+		if !bytes.Equal(got, b) {
+			t.Fatalf("round trip = %x, want %x", got, b)
+		}
+	}
+}
+
+func TestDecodeCrockford_Invalid(t *testing.T) {
+	cases := []string{
+		"",
+		"0123456789ABCDEFGHJKMNPQR",  // too short
+		"0123456789ABCDEFGHJKMNPQR!", // invalid character
+		"0123456789ABCDEFGHJKMNPQRU", // U is excluded
+		"80000000000000000000000000", // exceeds 128 bits
+	}
+	for _, s := range cases {
+		if _, err := decodeCrockford(s); err == nil {
+			t.Fatalf("decodeCrockford(%q) expected error", s)
+		}
+	}
+}
+
+func TestBase62RoundTrip(t *testing.T) {
+	code, err := ToBase62("550e8400-e29b-41d4-a716-446655440000")
+	if err != nil {
+		t.Fatalf("ToBase62 error: %v", err)
+	}
+	if code != "2aUyqjCzEIiEcYMKj7TZtw" {
+		t.Fatalf("ToBase62 = %s, want 2aUyqjCzEIiEcYMKj7TZtw", code)
+	}
+	got, err := FromBase62(code)
+	if err != nil {
+		t.Fatalf("FromBase62 error: %v", err)
+	}
+	if got != "550e8400-e29b-41d4-a716-446655440000" {
+		t.Fatalf("FromBase62 = %s", got)
+	}
+
+	nilCode, _ := ToBase62("00000000000000000000000000000000")
+	if nilCode != "0000000000000000000000" {
+		t.Fatalf("ToBase62(nil) = %s", nilCode)
+	}
+}
+
+func TestFromBase62All(t *testing.T) {
+	id := UuidV4(true)
+	code, err := ToBase62(id)
+	if err != nil {
+		t.Fatalf("ToBase62 error: %v", err)
+	}
+	codes := []string{
+		code,
+		"zzzzzzzzzzzzzzzzzzzzzz", // decodes to more than 16 bytes
+		"2aUyqjCzEIiEcYMKj7TZt!", // invalid character
+		"2aUyqjCzEIiEcYMKj7TZtw",
+	}
+
+	uuids, errs := FromBase62All(codes)
+	if len(uuids) != len(codes) || len(errs) != len(codes) {
+		t.Fatalf("FromBase62All returned %d uuids and %d errors, want %d", len(uuids), len(errs), len(codes))
+	}
+	if errs[0] != nil || uuids[0] != id {
+		t.Fatalf("entry 0 = %q, %v; want %s", uuids[0], errs[0], id)
+	}
+	if errs[1] == nil || uuids[1] != "" {
+		t.Fatalf("entry 1 = %q, %v; want over-length error", uuids[1], errs[1])
+	}
+	if errs[2] == nil || uuids[2] != "" {
+		t.Fatalf("entry 2 = %q, %v; want invalid character error", uuids[2], errs[2])
+	}
+	if errs[3] != nil || uuids[3] != "550e8400-e29b-41d4-a716-446655440000" {
+		t.Fatalf("entry 3 = %q, %v", uuids[3], errs[3])
+	}
+}
+
+func TestBase32RoundTrip(t *testing.T) {
+	code, err := ToBase32("550e8400-e29b-41d4-a716-446655440000")
+	if err != nil {
+		t.Fatalf("ToBase32 error: %v", err)
+	}
+	if code != "2N1T201RMV87AAE5J4CSAM8000" {
+		t.Fatalf("ToBase32 = %s, want 2N1T201RMV87AAE5J4CSAM8000", code)
+	}
+	for _, in := range []string{code, strings.ToLower(code)} {
+		got, err := ParseBase32(in)
+		if err != nil {
+			t.Fatalf("ParseBase32(%s) error: %v", in, err)
+		}
+		if got != "550e8400-e29b-41d4-a716-446655440000" {
+			t.Fatalf("ParseBase32(%s) = %s", in, got)
+		}
+	}
+
+	for i := 0; i < 100; i++ {
+		id := UuidV4(true)
+		code, err := ToBase32(id)
+		if err != nil {
+			t.Fatalf("ToBase32 error: %v", err)
+		}
+		if len(code) != 26 {
+			t.Fatalf("ToBase32 length = %d, want 26", len(code))
+		}
+		if got, _ := ParseBase32(code); got != id {
+			t.Fatalf("round trip = %s, want %s", got, id)
+		}
+	}
+}
+
+func TestToBase32Truncated(t *testing.T) {
+	id := "550e8400-e29b-41d4-a716-446655440000"
+	got, err := ToBase32Truncated(id, 20)
+	if err != nil {
+		t.Fatalf("ToBase32Truncated error: %v", err)
+	}
+	if got != "2N1T201RMV87AAE5J4CS" {
+		t.Fatalf("ToBase32Truncated = %s, want 2N1T201RMV87AAE5J4CS", got)
+	}
+	if _, err := ParseBase32(got); err == nil {
+		t.Fatal("a truncated code must not parse back to a UUID")
+	}
+
+	// distinct UUIDs differing only in the dropped bits share the truncated form
+	other := "550e8400-e29b-41d4-a716-446655440fff"
+	if o, _ := ToBase32Truncated(other, 20); o != got {
+		t.Fatalf("expected lossy collision, got %s and %s", got, o)
+	}
+
+	for _, n := range []int{0, 27} {
+		if _, err := ToBase32Truncated(id, n); err == nil {
+			t.Fatalf("ToBase32Truncated(%d) expected error", n)
+		}
+	}
+}
+
+func TestToQRCode_RoundTrip(t *testing.T) {
+	const id = "550e8400-e29b-41d4-a716-446655440000"
+	code, err := ToQRCode(id)
+	if err != nil {
+		t.Fatalf("ToQRCode error: %v", err)
+	}
+	if code != "2N1T201RMV87AAE5J4CSAM8000" {
+		t.Fatalf("ToQRCode = %q", code)
+	}
+	for i := 0; i < 100; i++ {
+		id := UuidV7(true)
+		code, err := ToQRCode(id)
+		if err != nil {
+			t.Fatalf("ToQRCode(%q) error: %v", id, err)
+		}
+		if len(code) != 26 || code != strings.ToUpper(code) {
+			t.Fatalf("ToQRCode(%q) = %q, want 26 uppercase characters", id, code)
+		}
+		back, err := FromQRCode(code)
+		if err != nil {
+			t.Fatalf("FromQRCode(%q) error: %v", code, err)
+		}
+		if back != id {
+			t.Fatalf("round trip: got %q want %q", back, id)
+		}
+	}
+}
+
+func TestQRCode_Invalid(t *testing.T) {
+	if _, err := ToQRCode("not-a-uuid"); err == nil {
+		t.Fatal("ToQRCode expected error")
+	}
+	for _, code := range []string{"", "2N1T201RMV87AAE5J4CSAM800", "2N1T201RMV87AAE5J4CSAM800U", "ZZZZZZZZZZZZZZZZZZZZZZZZZZ"} {
+		if _, err := FromQRCode(code); err == nil {
+			t.Fatalf("FromQRCode(%q) expected error", code)
+		}
+	}
+}
+
+func TestUuidBase32(t *testing.T) {
+	seen := map[string]bool{}
+	for i := 0; i < 100; i++ {
+		code := UuidBase32()
+		if len(code) != crockfordLength {
+			t.Fatalf("UuidBase32() = %q, length %d", code, len(code))
+		}
+		if strings.ContainsAny(code, "ILOU") {
+			t.Fatalf("UuidBase32() = %q contains an excluded character", code)
+		}
+		id, err := ParseBase32(code)
+		if err != nil {
+			t.Fatalf("ParseBase32(%q) error: %v", code, err)
+		}
+		assertLenAndVersion(t, id, 36, '4', true)
+		if back, _ := ToBase32(id); back != code {
+			t.Fatalf("round trip: got %q want %q", back, code)
+		}
+		seen[code] = true
+	}
+	if len(seen) != 100 {
+		t.Fatalf("UuidBase32 produced duplicates: %d unique of 100", len(seen))
+	}
+}
+
+func TestBase58RoundTrip(t *testing.T) {
+	const id = "550e8400-e29b-41d4-a716-446655440000"
+	got, err := ParseBase58("BWBeN28Vb7cMEx7Ym8AUzs")
+	if err != nil || got != id {
+		t.Fatalf("ParseBase58 = %q, %v; want %q", got, err, id)
+	}
+
+	for i := 0; i < 100; i++ {
+		code := UuidBase58()
+		if len(code) != base58Length {
+			t.Fatalf("UuidBase58() = %q, length %d", code, len(code))
+		}
+		id, err := ParseBase58(code)
+		if err != nil {
+			t.Fatalf("ParseBase58(%q) error: %v", code, err)
+		}
+		assertLenAndVersion(t, id, 36, '4', true)
+		b, _ := Parse(id)
+		if back := encodeBase(b, base58Alphabet, base58Length); back != code {
+			t.Fatalf("round trip: got %q want %q", back, code)
+		}
+	}
+
+	if got, err := ParseBase58("1"); err != nil || got != NilUUID() {
+		t.Fatalf("ParseBase58(\"1\") = %q, %v", got, err)
+	}
+}
+
+func TestParseBase58_Invalid(t *testing.T) {
+	cases := []string{
+		"",
+		"0WBeN28Vb7cMEx7Ym8AUzs", // 0 is not in the alphabet
+		"BWBeN28Vb7cMEx7Ym8AUzO", // nor O
+		"BWBeN28Vb7cMEx7Ym8AUzI", // nor I
+		"BWBeN28Vb7cMEx7Ym8AUzl", // nor l
+		"BWBeN28Vb7cMEx7Ym8AUz-",
+		"zzzzzzzzzzzzzzzzzzzzzz", // exceeds 128 bits
+	}
+	for _, c := range cases {
+		if _, err := ParseBase58(c); err == nil {
+			t.Fatalf("ParseBase58(%q) expected error", c)
+		}
+	}
+}
+
+func TestBase64RoundTrip(t *testing.T) {
+	const id = "550e8400-e29b-41d4-a716-446655440000"
+	got, err := ParseBase64("VQ6EAOKbQdSnFkRmVUQAAA")
+	if err != nil || got != id {
+		t.Fatalf("ParseBase64 = %q, %v; want %q", got, err, id)
+	}
+
+	for i := 0; i < 100; i++ {
+		code := UuidBase64()
+		if len(code) != 22 || strings.ContainsAny(code, "+/=") {
+			t.Fatalf("UuidBase64() = %q is not 22 URL-safe characters", code)
+		}
+		id, err := ParseBase64(code)
+		if err != nil {
+			t.Fatalf("ParseBase64(%q) error: %v", code, err)
+		}
+		assertLenAndVersion(t, id, 36, '4', true)
+	}
+}
+
+func TestParseBase64_Invalid(t *testing.T) {
+	cases := []string{
+		"",
+		"VQ6EAOKbQdSnFkRmVUQAAA==", // padded
+		"VQ6EAOKbQdSnFkRmVUQA",     // 15 bytes
+		"VQ6EAOKbQdSnFkRmVUQAAAAA", // 18 bytes
+		"VQ6EAOKbQdSnFkRmVUQAAB",   // non-zero trailing bits
+		"VQ6EAOKbQdSnFkRmVUQAA+",   // standard, not URL-safe, alphabet
+	}
+	for _, c := range cases {
+		if _, err := ParseBase64(c); err == nil {
+			t.Fatalf("ParseBase64(%q) expected error", c)
+		}
+	}
+}
+
+func TestUuidShort(t *testing.T) {
+	const id = "550e8400-e29b-41d4-a716-446655440000"
+	if got, err := UuidShortToCanonical("H9cNmGXLEc8NWcZzSThA9S"); err != nil || got != id {
+		t.Fatalf("UuidShortToCanonical = %q, %v; want %q", got, err, id)
+	}
+
+	for i := 0; i < 100; i++ {
+		code := UuidShort()
+		if len(code) != 22 {
+			t.Fatalf("UuidShort() = %q, length %d", code, len(code))
+		}
+		id, err := UuidShortToCanonical(code)
+		if err != nil {
+			t.Fatalf("UuidShortToCanonical(%q) error: %v", code, err)
+		}
+		assertLenAndVersion(t, id, 36, '4', true)
+		b, _ := Parse(id)
+		if back := encodeBase(b, base57Alphabet, shortLength); back != code {
+			t.Fatalf("round trip: got %q want %q", back, code)
+		}
+	}
+
+	// the nil and max UUIDs span the full width
+	for _, id := range []string{NilUUID(), MaxUUID()} {
+		b, _ := Parse(id)
+		code := encodeBase(b, base57Alphabet, shortLength)
+		if got, err := UuidShortToCanonical(code); err != nil || got != id {
+			t.Fatalf("UuidShortToCanonical(%q) = %q, %v; want %q", code, got, err, id)
+		}
+	}
+}
+
+func TestUuidShortToCanonical_Invalid(t *testing.T) {
+	cases := []string{
+		"",
+		"H9cNmGXLEc8NWcZzSThA9",   // too short
+		"H9cNmGXLEc8NWcZzSThA9SS", // too long
+		"H9cNmGXLEc8NWcZzSThA91",  // 1 is not in the alphabet
+		"zzzzzzzzzzzzzzzzzzzzzz",  // exceeds 128 bits
+	}
+	for _, c := range cases {
+		if _, err := UuidShortToCanonical(c); err == nil {
+			t.Fatalf("UuidShortToCanonical(%q) expected error", c)
+		}
+	}
+}
@@ -0,0 +1,159 @@
+package uid
+
+import "sync/atomic"
+
+// upperCase holds the package-wide casing policy set by SetDefaultCase.
+var upperCase atomic.Bool
+
+// SetDefaultCase sets the casing of the hexadecimal digits in every UUID
+// string produced by the package, formatted or not. The default is
+// lowercase. Functions documented to return the canonical form always
+// return lowercase. It is safe to call concurrently with generation and is meant
+// to be called once at startup.
+//
+// Parameters:
+// - upper: true for uppercase output, false for lowercase
+func SetDefaultCase(upper bool) {
+	upperCase.Store(upper)
+}
+
+// upperHex uppercases the lowercase hex digits in b.
+func upperHex(b []byte) {
+	for i, c := range b {
+		if c >= 'a' && c <= 'f' {
+			b[i] = c - 'a' + 'A'
+		}
+	}
+}
+
+// FastRemoveHyphens converts a canonical 36-character hyphenated UUID into
+// the 32-character bare form by dropping the hyphens at their fixed
+// positions.
+//
+// The input is NOT validated: it must already be a well-formed hyphenated
+// UUID. Other inputs produce garbage or panic. Never use it on untrusted
+// input; use RemoveHyphens instead.
+//
+// Example: 550e8400-e29b-41d4-a716-446655440000 => 550e8400e29b41d4a716446655440000
+//
+// Parameters:
+// - s: a trusted, canonical hyphenated UUID
+//
+// Returns:
+// - The UUID without hyphens
+func FastRemoveHyphens(s string) string {
+	var out [32]byte
+	copy(out[0:8], s[0:8])
+	copy(out[8:12], s[9:13])
+	copy(out[12:16], s[14:18])
+	copy(out[16:20], s[19:23])
+	copy(out[20:32], s[24:36])
+	return string(out[:])
+}
+
+// RemoveHyphens validates s and returns it in the 32-character bare form.
+//
+// Example: 550e8400-e29b-41d4-a716-446655440000 => 550e8400e29b41d4a716446655440000
+//
+// Parameters:
+// - s: a UUID in any form accepted by ParseWithFormat
+//
+// Returns:
+// - The lowercase UUID without hyphens, regardless of SetDefaultCase, or
+// an error if s is invalid
+func RemoveHyphens(s string) (string, error) {
+	b, _, err := ParseWithFormat(s)
+	if err != nil {
+		return "", err
+	}
+	return encodeUUID(b, false, false), nil
+}
+
+// UuidURN returns s in the URN form of RFC 4122, as required by SCIM and
+// some XML schemas. Parse accepts the result.
+//
+// Example: UuidURN("550E8400E29B41D4A716446655440000") => urn:uuid:550e8400-e29b-41d4-a716-446655440000
+//
+// Parameters:
+// - s: a UUID in any form accepted by Parse
+//
+// Returns:
+// - "urn:uuid:" followed by the canonical hyphenated UUID, or an error if
+// s is invalid
+func UuidURN(s string) (string, error) {
+	b, err := Parse(s)
+	if err != nil {
+		return "", err
+	}
+	return "urn:uuid:" + canonicalString(b), nil
+}
+
+// UuidBraced returns s wrapped in curly braces, the registry GUID form used
+// by Windows and .NET. Parse strips the braces again.
+//
+// Example: UuidBraced("550e8400e29b41d4a716446655440000") => {550e8400-e29b-41d4-a716-446655440000}
+//
+// Parameters:
+// - s: a UUID in any form accepted by Parse
+//
+// Returns:
+// - The canonical hyphenated UUID in braces, or an error if s is invalid
+func UuidBraced(s string) (string, error) {
+	b, err := Parse(s)
+	if err != nil {
+		return "", err
+	}
+	return "{" + canonicalString(b) + "}", nil
+}
+
+// UuidUpper returns a random UUID (version 4) with uppercase hex digits,
+// regardless of SetDefaultCase.
+//
+// Example: 550E8400E29B41D4A716446655440000 (length: 32)
+//
+// Parameters:
+// - formatted: when true, include hyphens
+//
+// Returns:
+// - An uppercase UUID v4
+func UuidUpper(formatted ...bool) string {
+	withHyphens := len(formatted) > 0 && formatted[0]
+	return encodeUUID(newV4(), withHyphens, true)
+}
+
+// ToUpper validates s and returns it with uppercase hex digits. A bare
+// input stays bare; any other form becomes hyphenated.
+//
+// Example: 550e8400-e29b-41d4-a716-446655440000 => 550E8400-E29B-41D4-A716-446655440000
+//
+// Parameters:
+// - s: a UUID in any form accepted by ParseWithFormat
+//
+// Returns:
+// - The uppercase UUID, or an error if s is invalid
+func ToUpper(s string) (string, error) {
+	return toCase(s, true)
+}
+
+// ToLower validates s and returns it with lowercase hex digits. A bare
+// input stays bare; any other form becomes hyphenated.
+//
+// Example: 550E8400E29B41D4A716446655440000 => 550e8400e29b41d4a716446655440000
+//
+// Parameters:
+// - s: a UUID in any form accepted by ParseWithFormat
+//
+// Returns:
+// - The lowercase UUID, or an error if s is invalid
+func ToLower(s string) (string, error) {
+	return toCase(s, false)
+}
+
+// toCase implements ToUpper and ToLower.
+func toCase(s string, upper bool) (string, error) {
+	b, format, err := ParseWithFormat(s)
+	if err != nil {
+		return "", err
+	}
+	return encodeUUID(b, format != FormatBare, upper), nil
+}
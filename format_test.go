@@ -0,0 +1,196 @@
+package uid
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestFastRemoveHyphens(t *testing.T) {
+	got := FastRemoveHyphens("550e8400-e29b-41d4-a716-446655440000")
+	if got != "550e8400e29b41d4a716446655440000" {
+		t.Fatalf("FastRemoveHyphens = %s", got)
+	}
+
+	for i := 0; i < 100; i++ {
+		id := UuidV4(true)
+		want, err := RemoveHyphens(id)
+		if err != nil {
+			t.Fatalf("RemoveHyphens(%s) error: %v", id, err)
+		}
+		if got := FastRemoveHyphens(id); got != want {
+			t.Fatalf("FastRemoveHyphens(%s) = %s, want %s", id, got, want)
+		}
+	}
+}
+
+func TestRemoveHyphens(t *testing.T) {
+	got, err := RemoveHyphens("{550E8400-E29B-41D4-A716-446655440000}")
+	if err != nil {
+		t.Fatalf("RemoveHyphens error: %v", err)
+	}
+	if got != "550e8400e29b41d4a716446655440000" {
+		t.Fatalf("RemoveHyphens = %s", got)
+	}
+	if _, err := RemoveHyphens("550e8400-e29b-41d4-a716-44665544000z"); err == nil {
+		t.Fatal("RemoveHyphens expected error for invalid UUID")
+	}
+}
+
+func TestRemoveHyphens_IgnoresDefaultCase(t *testing.T) {
+	defer SetDefaultCase(false)
+	for _, upper := range []bool{false, true} {
+		SetDefaultCase(upper)
+		got, err := RemoveHyphens("550e8400-e29b-41d4-a716-446655440000")
+		if err != nil {
+			t.Fatalf("RemoveHyphens error: %v", err)
+		}
+		if got != "550e8400e29b41d4a716446655440000" {
+			t.Fatalf("RemoveHyphens with SetDefaultCase(%v) = %s, want lowercase", upper, got)
+		}
+	}
+}
+
+func BenchmarkFastRemoveHyphens(b *testing.B) {
+	id := "550e8400-e29b-41d4-a716-446655440000"
+	for i := 0; i < b.N; i++ {
+		FastRemoveHyphens(id)
+	}
+}
+
+func BenchmarkRemoveHyphens(b *testing.B) {
+	id := "550e8400-e29b-41d4-a716-446655440000"
+	for i := 0; i < b.N; i++ {
+		_, _ = RemoveHyphens(id)
+	}
+}
+
+func TestSetDefaultCase(t *testing.T) {
+	t.Cleanup(func() { SetDefaultCase(false) })
+
+	lower := UuidV4(true)
+	if lower != strings.ToLower(lower) {
+		t.Fatalf("default output must be lowercase: %s", lower)
+	}
+
+	SetDefaultCase(true)
+	upper := UuidV7(true)
+	if upper != strings.ToUpper(upper) {
+		t.Fatalf("output must be uppercase after SetDefaultCase(true): %s", upper)
+	}
+	assertLenAndVersion(t, upper, 36, '7', true)
+	bare := UuidV4()
+	if bare != strings.ToUpper(bare) {
+		t.Fatalf("unformatted output must be uppercase after SetDefaultCase(true): %s", bare)
+	}
+	if !Equal(upper, strings.ToLower(upper)) {
+		t.Fatal("Equal must ignore casing")
+	}
+
+	SetDefaultCase(false)
+	lower = UuidV1(true)
+	if lower != strings.ToLower(lower) {
+		t.Fatalf("output must be lowercase after SetDefaultCase(false): %s", lower)
+	}
+}
+
+func TestUuidURN(t *testing.T) {
+	const want = "urn:uuid:550e8400-e29b-41d4-a716-446655440000"
+	for _, in := range []string{
+		"550e8400-e29b-41d4-a716-446655440000",
+		"550E8400E29B41D4A716446655440000",
+		"{550e8400-e29b-41d4-a716-446655440000}",
+		want,
+	} {
+		got, err := UuidURN(in)
+		if err != nil {
+			t.Fatalf("UuidURN(%q) error: %v", in, err)
+		}
+		if got != want {
+			t.Fatalf("UuidURN(%q) = %q, want %q", in, got, want)
+		}
+	}
+
+	b, format, err := ParseWithFormat(want)
+	if err != nil || format != FormatURN || canonicalString(b) != want[len("urn:uuid:"):] {
+		t.Fatalf("ParseWithFormat(%q) = %x, %q, %v", want, b, format, err)
+	}
+
+	if _, err := UuidURN("urn:uuid:not-a-uuid"); err == nil {
+		t.Fatal("UuidURN expected error for invalid input")
+	}
+}
+
+func TestUuidBraced(t *testing.T) {
+	const want = "{550e8400-e29b-41d4-a716-446655440000}"
+	got, err := UuidBraced("550E8400-E29B-41D4-A716-446655440000")
+	if err != nil {
+		t.Fatalf("UuidBraced error: %v", err)
+	}
+	if got != want {
+		t.Fatalf("UuidBraced = %q, want %q", got, want)
+	}
+
+	for i := 0; i < 100; i++ {
+		id := UuidV4(true)
+		braced, err := UuidBraced(id)
+		if err != nil {
+			t.Fatalf("UuidBraced(%q) error: %v", id, err)
+		}
+		b, err := Parse(braced)
+		if err != nil {
+			t.Fatalf("Parse(%q) error: %v", braced, err)
+		}
+		if canonicalString(b) != id {
+			t.Fatalf("round trip: got %q want %q", canonicalString(b), id)
+		}
+	}
+
+	for _, in := range []string{"", "{}", "{550e8400-e29b-41d4-a716-44665544000g}"} {
+		if _, err := UuidBraced(in); err == nil {
+			t.Fatalf("UuidBraced(%q) expected error", in)
+		}
+	}
+}
+
+func TestUuidUpper(t *testing.T) {
+	for _, formatted := range []bool{false, true} {
+		s := UuidUpper(formatted)
+		if s != strings.ToUpper(s) {
+			t.Fatalf("UuidUpper(%v) = %q is not uppercase", formatted, s)
+		}
+		if !IsValid(s) {
+			t.Fatalf("UuidUpper(%v) = %q is not valid", formatted, s)
+		}
+		b, err := Parse(s)
+		if err != nil {
+			t.Fatalf("Parse(%q) error: %v", s, err)
+		}
+		if got := encodeUUID(b, formatted, true); got != s {
+			t.Fatalf("round trip: got %q want %q", got, s)
+		}
+	}
+}
+
+func TestToUpperToLower(t *testing.T) {
+	cases := []struct {
+		in, upper, lower string
+	}{
+		{"550e8400e29b41d4a716446655440000", "550E8400E29B41D4A716446655440000", "550e8400e29b41d4a716446655440000"},
+		{"550E8400-e29b-41D4-a716-446655440000", "550E8400-E29B-41D4-A716-446655440000", "550e8400-e29b-41d4-a716-446655440000"},
+		{"{550e8400-e29b-41d4-a716-446655440000}", "550E8400-E29B-41D4-A716-446655440000", "550e8400-e29b-41d4-a716-446655440000"},
+	}
+	for _, c := range cases {
+		if got, err := ToUpper(c.in); err != nil || got != c.upper {
+			t.Fatalf("ToUpper(%q) = %q, %v; want %q", c.in, got, err, c.upper)
+		}
+		if got, err := ToLower(c.in); err != nil || got != c.lower {
+			t.Fatalf("ToLower(%q) = %q, %v; want %q", c.in, got, err, c.lower)
+		}
+	}
+	if _, err := ToUpper("invalid"); err == nil {
+		t.Fatal("ToUpper expected error for invalid input")
+	}
+	if _, err := ToLower("invalid"); err == nil {
+		t.Fatal("ToLower expected error for invalid input")
+	}
+}
@@ -0,0 +1,106 @@
+package uid
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// generators maps the names accepted by Generate to their generators. It
+// is derived from uuidVersions, uidKinds and the ULID, so Generate accepts
+// exactly the versions reported by SupportedVersions. args are the extra
+// arguments passed to Generate.
+var generators = func() map[string]func(args []string) (string, error) {
+	m := map[string]func(args []string) (string, error){"ulid": noArgs(Ulid)}
+	for _, v := range uuidVersions {
+		gen, generate := v.fromInput, v.generate
+		if gen == nil {
+			gen = noArgs(func() string { return bytesToUUIDString(generate(), false) })
+		}
+		m["v"+strconv.Itoa(v.version)] = gen
+	}
+	for name, k := range uidKinds {
+		length, pause := k.length, k.pause
+		m[name] = noArgs(func() string { return newUid(length, pause) })
+	}
+	return m
+}()
+
+// noArgs adapts a generator without input, rejecting extra arguments.
+func noArgs(gen func() string) func(args []string) (string, error) {
+	return func(args []string) (string, error) {
+		if len(args) != 0 {
+			return "", fmt.Errorf("takes no arguments, got %d", len(args))
+		}
+		return gen(), nil
+	}
+}
+
+// nameBased adapts UuidV3FromString or UuidV5FromString, which take a
+// namespace UUID and a name.
+func nameBased(gen func(string, []byte, ...bool) (string, error)) func(args []string) (string, error) {
+	return func(args []string) (string, error) {
+		if len(args) != 2 {
+			return "", fmt.Errorf("takes a namespace UUID and a name, got %d arguments", len(args))
+		}
+		return gen(args[0], []byte(args[1]))
+	}
+}
+
+// customBytes adapts UuidV8, which takes the 16 bytes of the UUID, given
+// as a UUID in any form accepted by ParseWithFormat.
+func customBytes(args []string) (string, error) {
+	if len(args) != 1 {
+		return "", fmt.Errorf("takes the 16 bytes as a UUID, got %d arguments", len(args))
+	}
+	b, _, err := ParseWithFormat(args[0])
+	if err != nil {
+		return "", err
+	}
+	return UuidV8(b)
+}
+
+// Generate returns a new ID of the named kind, a single entry point for
+// command-line tools. IDs are unformatted, as from the matching function
+// called without arguments.
+//
+// Names: "v1", "v4", "v6", "v7", "ulid", "human", "nano", "micro", "sec",
+// the name-based "v3" and "v5", which take a namespace UUID and a name as
+// args, and "v8", which takes its 16 bytes as a UUID string.
+//
+// Example: Generate("v5", "6ba7b810-9dad-11d1-80b4-00c04fd430c8", "www.example.com") => "2ed6657de927568b95e12665a8aea6a2"
+//
+// Parameters:
+// - version: the kind of ID, case-insensitive
+// - args: the namespace UUID and name for "v3" and "v5", the bytes for
+// "v8"; none otherwise
+//
+// Returns:
+// - The ID, or an error for an unknown name or wrong arguments
+func Generate(version string, args ...string) (string, error) {
+	name := strings.ToLower(version)
+	gen, ok := generators[name]
+	if !ok {
+		return "", fmt.Errorf("unknown ID kind %q, expected one of %s", version, strings.Join(GenerateNames(), ", "))
+	}
+	id, err := gen(args)
+	if err != nil {
+		return "", fmt.Errorf("%s: %w", name, err)
+	}
+	return id, nil
+}
+
+// GenerateNames returns the names accepted by Generate, sorted, for help
+// texts.
+//
+// Returns:
+// - A new slice of names
+func GenerateNames() []string {
+	names := make([]string, 0, len(generators))
+	for name := range generators {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,120 @@
+package uid
+
+import (
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestGenerate(t *testing.T) {
+	cases := map[string]func(t *testing.T, id string){
+		"v1":    func(t *testing.T, id string) { assertLenAndVersion(t, id, 32, '1', false) },
+		"v4":    func(t *testing.T, id string) { assertLenAndVersion(t, id, 32, '4', false) },
+		"V6":    func(t *testing.T, id string) { assertLenAndVersion(t, id, 32, '6', false) },
+		"v7":    func(t *testing.T, id string) { assertLenAndVersion(t, id, 32, '7', false) },
+		"ulid":  func(t *testing.T, id string) { assertUlid(t, id) },
+		"human": func(t *testing.T, id string) { assertNumeric(t, id, humanUidLength) },
+		"nano":  func(t *testing.T, id string) { assertNumeric(t, id, nanoUidLength) },
+		"micro": func(t *testing.T, id string) { assertNumeric(t, id, microUidLength) },
+	}
+	for name, check := range cases {
+		id, err := Generate(name)
+		if err != nil {
+			t.Fatalf("Generate(%q) error: %v", name, err)
+		}
+		check(t, id)
+	}
+
+	// sec sleeps a second under the default strategy
+	SetUidStrategy(UidStrategyRandom)
+	t.Cleanup(func() { SetUidStrategy(UidStrategySleep) })
+	if id, err := Generate("sec"); err != nil || !IsNumericUid(id) || len(id) != secUidLength {
+		t.Fatalf("Generate(\"sec\") = %q, %v", id, err)
+	}
+}
+
+func TestGenerate_NameBased(t *testing.T) {
+	const ns = "6ba7b810-9dad-11d1-80b4-00c04fd430c8"
+	if id, err := Generate("v5", ns, "www.example.com"); err != nil || id != "2ed6657de927568b95e12665a8aea6a2" {
+		t.Fatalf("Generate(\"v5\") = %q, %v", id, err)
+	}
+	if id, err := Generate("v3", ns, "www.example.com"); err != nil || id != "5df418813aed351588a72f4a814cf09e" {
+		t.Fatalf("Generate(\"v3\") = %q, %v", id, err)
+	}
+	for _, args := range [][]string{nil, {ns}, {"not-a-uuid", "name"}, {ns, "a", "b"}} {
+		if _, err := Generate("v5", args...); err == nil {
+			t.Fatalf("Generate(\"v5\", %q) expected error", args)
+		}
+	}
+}
+
+func TestGenerate_Errors(t *testing.T) {
+	for _, name := range []string{"", "v2", "v9", "uuid", "nanoid"} {
+		_, err := Generate(name)
+		if err == nil || !strings.Contains(err.Error(), "unknown") {
+			t.Fatalf("Generate(%q) error = %v, want an unknown kind error", name, err)
+		}
+	}
+	if _, err := Generate("v4", "extra"); err == nil {
+		t.Fatal("Generate(\"v4\", \"extra\") expected error")
+	}
+}
+
+func TestGenerateNames(t *testing.T) {
+	names := GenerateNames()
+	if got := strings.Join(names, ","); got != "human,micro,nano,sec,ulid,v1,v3,v4,v5,v6,v7,v8" {
+		t.Fatalf("GenerateNames() = %s", got)
+	}
+}
+
+func assertUlid(t *testing.T, id string) {
+	t.Helper()
+	if _, err := ParseUlid(id); err != nil {
+		t.Fatalf("%q is not a ULID: %v", id, err)
+	}
+}
+
+func assertNumeric(t *testing.T, id string, length int) {
+	t.Helper()
+	if len(id) != length || !IsNumericUid(id) {
+		t.Fatalf("%q is not a %d-digit ID", id, length)
+	}
+}
+
+func TestGenerate_V8(t *testing.T) {
+	id, err := Generate("v8", "550e8400-e29b-41d4-a716-446655440000")
+	if err != nil || id != "550e8400e29b81d4a716446655440000" {
+		t.Fatalf("Generate(\"v8\") = %q, %v", id, err)
+	}
+	for _, args := range [][]string{nil, {"not-a-uuid"}, {"550e8400-e29b-41d4-a716-446655440000", "x"}} {
+		if _, err := Generate("v8", args...); err == nil {
+			t.Fatalf("Generate(\"v8\", %q) expected error", args)
+		}
+	}
+}
+
+func TestGenerateNames_MatchSupportedVersions(t *testing.T) {
+	names := GenerateNames()
+	accepted := make(map[string]bool, len(names))
+	for _, name := range names {
+		accepted[name] = true
+	}
+	supported := make(map[string]bool)
+	for _, v := range SupportedVersions() {
+		name := "v" + strconv.Itoa(v)
+		supported[name] = true
+		if !accepted[name] {
+			t.Fatalf("SupportedVersions reports %d, but Generate does not accept %q", v, name)
+		}
+	}
+	for _, name := range names {
+		if strings.HasPrefix(name, "v") && !supported[name] {
+			t.Fatalf("Generate accepts %q, but SupportedVersions does not report it", name)
+		}
+	}
+	for kind := range uidKinds {
+		if !accepted[kind] {
+			t.Fatalf("Generate does not accept the Uid kind %q", kind)
+		}
+	}
+}
@@ -0,0 +1,394 @@
+package uid
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+)
+
+// Generator produces UUIDs from its own state instead of the package-level
+// state used by the UuidVx functions. Unlike those functions, Generator
+// methods report randomness failures as errors instead of degrading.
+//
+// The zero value is ready to use. A Generator is safe for concurrent use.
+type Generator struct {
+	// Reader is the source of randomness. When nil, the package Reader
+	// (crypto/rand.Reader by default) is used.
+	Reader io.Reader
+
+	mu  sync.Mutex
+	now func() time.Time // nil means nowFunc
+
+	// V7Node state
+	nodeLastMs  uint64
+	nodeCounter uint16
+
+	epoch time.Time // custom epoch of V7Epoch; zero means the Unix epoch
+
+	// V1/V6 state, see NewGenerator; a zero value uses the package node ID
+	// and a random initial clock sequence
+	node        [6]byte
+	hasNode     bool
+	clockSeq    uint16
+	hasClockSeq bool
+	lastTime    uint64
+
+	upper       bool // casing when hasCase, else the package default
+	hasCase     bool
+	uidStrategy string // strategy of Uid; empty means the package strategy
+}
+
+// V4 returns a random UUID (version 4).
+//
+// Parameters:
+// - formatted: when true, include hyphens
+//
+// Returns:
+// - The UUID v4 as a string, or the error of the random source
+func (g *Generator) V4(formatted ...bool) (string, error) {
+	b := make([]byte, 16)
+	if err := g.read(b); err != nil {
+		return "", err
+	}
+	setVersion(b, 4)
+	setVariantRFC4122(b)
+	withHyphens := len(formatted) > 0 && formatted[0]
+	return g.encode(b, withHyphens), nil
+}
+
+// v4CheckedAttempts is the number of draws V4Checked makes before giving up.
+const v4CheckedAttempts = 3
+
+// V4Checked is like V4 but rejects draws whose 16 random bytes are all
+// equal (including all zero), a sign of a broken random source, and draws
+// again up to v4CheckedAttempts times before returning an error.
+//
+// A healthy source trips the check with probability 2^-120 per draw, so a
+// false positive is practically impossible, let alone three in a row.
+//
+// Parameters:
+// - formatted: when true, include hyphens
+//
+// Returns:
+// - The UUID v4 as a string, or an error if the random source fails or
+// keeps producing degenerate output
+func (g *Generator) V4Checked(formatted ...bool) (string, error) {
+	b := make([]byte, 16)
+	for i := 0; i < v4CheckedAttempts; i++ {
+		if err := g.read(b); err != nil {
+			return "", err
+		}
+		if !allEqual(b) {
+			setVersion(b, 4)
+			setVariantRFC4122(b)
+			withHyphens := len(formatted) > 0 && formatted[0]
+			return g.encode(b, withHyphens), nil
+		}
+	}
+	return "", fmt.Errorf("random source produced degenerate output %d times in a row", v4CheckedAttempts)
+}
+
+// allEqual reports whether all bytes of b are the same.
+func allEqual(b []byte) bool {
+	for _, c := range b[1:] {
+		if c != b[0] {
+			return false
+		}
+	}
+	return true
+}
+
+// V7 returns a version 7 (Unix time-based) UUID.
+//
+// Parameters:
+// - formatted: when true, include hyphens
+//
+// Returns:
+// - The UUID v7 as a string, or the error of the random source
+func (g *Generator) V7(formatted ...bool) (string, error) {
+	b := make([]byte, 16)
+	if err := g.read(b[6:]); err != nil {
+		return "", err
+	}
+	putMillis(b, uint64(g.clock().UnixMilli()))
+	setVersion(b, 7)
+	setVariantRFC4122(b)
+	withHyphens := len(formatted) > 0 && formatted[0]
+	return g.encode(b, withHyphens), nil
+}
+
+// V7ZeroRandA returns a version 7 UUID whose 12-bit rand_a field is zero,
+// so all IDs of one millisecond share the same 8-byte prefix. This helps
+// storage engines that cluster keys by prefix.
+//
+// Only the 62 bits of rand_b are random, which lowers the entropy within a
+// millisecond from 74 to 62 bits.
+//
+// Parameters:
+// - formatted: when true, include hyphens
+//
+// Returns:
+// - The UUID v7 as a string, or the error of the random source
+func (g *Generator) V7ZeroRandA(formatted ...bool) (string, error) {
+	b := make([]byte, 16)
+	if err := g.read(b[8:]); err != nil {
+		return "", err
+	}
+	putMillis(b, uint64(g.clock().UnixMilli()))
+	b[6] = 0x70
+	b[7] = 0x00
+	setVariantRFC4122(b)
+	withHyphens := len(formatted) > 0 && formatted[0]
+	return g.encode(b, withHyphens), nil
+}
+
+// V6At returns a version 6 UUID for the explicit time t, for backfilling
+// time-sortable keys from historical records. The node is the one of V6
+// (the configured node, else the package node ID) and the clock sequence
+// is random, so repeated calls with the same time still differ.
+//
+// Parameters:
+// - t: the time to embed, not before 1582-10-15 (the Gregorian epoch)
+// - formatted: when true, include hyphens
+//
+// Returns:
+// - The UUID v6 as a string, or an error
+func (g *Generator) V6At(t time.Time, formatted ...bool) (string, error) {
+	ts, err := gregorian100ns(t)
+	if err != nil {
+		return "", err
+	}
+	var r [2]byte
+	if err := g.read(r[:]); err != nil {
+		return "", err
+	}
+	g.mu.Lock()
+	node := g.nodeIDLocked()
+	g.mu.Unlock()
+	b := make([]byte, 16)
+	putV6(b, ts, binary.BigEndian.Uint16(r[:])&0x3FFF, node[:])
+	withHyphens := len(formatted) > 0 && formatted[0]
+	return g.encode(b, withHyphens), nil
+}
+
+// SetEpoch sets the custom epoch used by V7Epoch and TimeFromV7Epoch. A
+// recent epoch keeps timestamps small, extending the usable range of the
+// 48-bit field (about 8900 years from the epoch).
+//
+// Parameters:
+// - t: the epoch; the zero time restores the Unix epoch
+func (g *Generator) SetEpoch(t time.Time) {
+	g.mu.Lock()
+	g.epoch = t
+	g.mu.Unlock()
+}
+
+// V7Epoch returns a version 7 style UUID whose 48-bit timestamp counts
+// milliseconds since the epoch set with SetEpoch instead of the Unix epoch.
+// Decode its time with TimeFromV7Epoch on a Generator with the same epoch;
+// TimeFromV7 would misread it.
+//
+// Parameters:
+// - formatted: when true, include hyphens
+//
+// Returns:
+// - The UUID as a string, or an error if the current time is before the
+// epoch or the random source fails
+func (g *Generator) V7Epoch(formatted ...bool) (string, error) {
+	g.mu.Lock()
+	epoch := g.epochOrUnix()
+	g.mu.Unlock()
+
+	ms := g.clock().Sub(epoch).Milliseconds()
+	if ms < 0 {
+		return "", errors.New("current time is before the generator epoch")
+	}
+	if ms >= 1<<48 {
+		return "", errors.New("current time exceeds the 48-bit range of the generator epoch")
+	}
+	b := make([]byte, 16)
+	if err := g.read(b[6:]); err != nil {
+		return "", err
+	}
+	putMillis(b, uint64(ms))
+	setVersion(b, 7)
+	setVariantRFC4122(b)
+	withHyphens := len(formatted) > 0 && formatted[0]
+	return g.encode(b, withHyphens), nil
+}
+
+// TimeFromV7Epoch returns the time embedded in a UUID produced by V7Epoch,
+// relative to the generator's epoch.
+//
+// Parameters:
+// - s: a UUID in any form accepted by ParseWithFormat
+//
+// Returns:
+// - The embedded time (UTC, millisecond resolution), or an error if s is
+// invalid or not a version 7 UUID
+func (g *Generator) TimeFromV7Epoch(s string) (time.Time, error) {
+	b, _, err := ParseWithFormat(s)
+	if err != nil {
+		return time.Time{}, err
+	}
+	if b[6]>>4 != 7 {
+		return time.Time{}, errors.New("not a version 7 UUID")
+	}
+	g.mu.Lock()
+	epoch := g.epochOrUnix()
+	g.mu.Unlock()
+	return epoch.Add(time.Duration(v7Millis(b)) * time.Millisecond).UTC(), nil
+}
+
+// MustV4 is like V4 but panics if the random source fails. It is meant for
+// initialization paths where a failure is fatal anyway.
+//
+// Parameters:
+// - formatted: when true, include hyphens
+//
+// Returns:
+// - The UUID v4 as a string
+func (g *Generator) MustV4(formatted ...bool) string {
+	s, err := g.V4(formatted...)
+	if err != nil {
+		panic("uid: V4: " + err.Error())
+	}
+	return s
+}
+
+// MustV7 is like V7 but panics if the random source fails. It is meant for
+// initialization paths where a failure is fatal anyway.
+//
+// Parameters:
+// - formatted: when true, include hyphens
+//
+// Returns:
+// - The UUID v7 as a string
+func (g *Generator) MustV7(formatted ...bool) string {
+	s, err := g.V7(formatted...)
+	if err != nil {
+		panic("uid: V7: " + err.Error())
+	}
+	return s
+}
+
+// MaxV7Node is the exclusive upper bound of node IDs accepted by V7Node.
+const MaxV7Node = 1024
+
+// V7Node returns a version 7 UUID that cannot collide with IDs generated
+// for any other node ID, even within the same millisecond.
+//
+// Layout (bit offsets from the most significant bit):
+//   - 0-47: Unix timestamp in milliseconds
+//   - 48-51: version (7)
+//   - 52-63: 12-bit counter, reset every millisecond and incremented per ID
+//   - 64-65: variant (10)
+//   - 66-75: 10-bit node ID
+//   - 76-127: 52 random bits
+//
+// When more than 4096 IDs are requested within one millisecond the
+// timestamp is advanced by one millisecond, so IDs stay strictly increasing
+// per Generator.
+//
+// Parameters:
+// - nodeID: the node identifier, must be below MaxV7Node
+// - formatted: when true, include hyphens
+//
+// Returns:
+// - The UUID v7 as a string, or an error
+func (g *Generator) V7Node(nodeID uint16, formatted ...bool) (string, error) {
+	if nodeID >= MaxV7Node {
+		return "", errors.New("node ID must be below 1024")
+	}
+
+	var r [7]byte
+	if err := g.read(r[:]); err != nil {
+		return "", err
+	}
+
+	g.mu.Lock()
+	ms := uint64(g.clock().UnixMilli())
+	if ms <= g.nodeLastMs {
+		ms = g.nodeLastMs
+		g.nodeCounter++
+		if g.nodeCounter > 0x0FFF {
+			ms++
+			g.nodeCounter = 0
+		}
+	} else {
+		g.nodeCounter = 0
+	}
+	g.nodeLastMs = ms
+	counter := g.nodeCounter
+	g.mu.Unlock()
+
+	b := make([]byte, 16)
+	putMillis(b, ms)
+	binary.BigEndian.PutUint16(b[6:8], 0x7000|counter)
+	b[8] = 0x80 | byte(nodeID>>4)
+	b[9] = byte(nodeID<<4) | r[0]&0x0F
+	copy(b[10:], r[1:])
+
+	withHyphens := len(formatted) > 0 && formatted[0]
+	return g.encode(b, withHyphens), nil
+}
+
+// NodeFromV7Node returns the node ID embedded by Generator.V7Node.
+//
+// Note that any version 7 UUID yields a value; for UUIDs not produced by
+// V7Node the result is just random bits.
+//
+// Parameters:
+// - s: a UUID in any form accepted by ParseWithFormat
+//
+// Returns:
+// - The 10-bit node ID, or an error if s is not a valid version 7 UUID
+func NodeFromV7Node(s string) (uint16, error) {
+	b, _, err := ParseWithFormat(s)
+	if err != nil {
+		return 0, err
+	}
+	if b[6]>>4 != 7 {
+		return 0, errors.New("not a version 7 UUID")
+	}
+	return uint16(b[8]&0x3F)<<4 | uint16(b[9]>>4), nil
+}
+
+// read fills b from the generator's random source.
+func (g *Generator) read(b []byte) error {
+	r := g.Reader
+	if r == nil {
+		r = Reader
+	}
+	_, err := io.ReadFull(r, b)
+	return err
+}
+
+// epochOrUnix returns the custom epoch, or the Unix epoch if none is set.
+// The caller must hold g.mu.
+func (g *Generator) epochOrUnix() time.Time {
+	if g.epoch.IsZero() {
+		return time.Unix(0, 0)
+	}
+	return g.epoch
+}
+
+// encode formats b in the generator's casing.
+func (g *Generator) encode(b []byte, withHyphens bool) string {
+	upper := upperCase.Load()
+	if g.hasCase {
+		upper = g.upper
+	}
+	return encodeUUID(b, withHyphens, upper)
+}
+
+// clock returns the current time of the generator.
+func (g *Generator) clock() time.Time {
+	if g.now != nil {
+		return g.now()
+	}
+	return nowFunc()
+}
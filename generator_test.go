@@ -0,0 +1,295 @@
+package uid
+
+import (
+	"bytes"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestGeneratorV7Node(t *testing.T) {
+	var g Generator
+	a, err := g.V7Node(42)
+	if err != nil {
+		t.Fatalf("V7Node error: %v", err)
+	}
+	assertLenAndVersion(t, a, 32, '7', false)
+
+	b, err := g.V7Node(1023, true)
+	if err != nil {
+		t.Fatalf("V7Node error: %v", err)
+	}
+	assertLenAndVersion(t, b, 36, '7', true)
+
+	if node, err := NodeFromV7Node(a); err != nil || node != 42 {
+		t.Fatalf("NodeFromV7Node(%s) = %d, %v; want 42", a, node, err)
+	}
+	if node, err := NodeFromV7Node(b); err != nil || node != 1023 {
+		t.Fatalf("NodeFromV7Node(%s) = %d, %v; want 1023", b, node, err)
+	}
+}
+
+func TestGeneratorV7Node_InvalidNode(t *testing.T) {
+	var g Generator
+	if _, err := g.V7Node(MaxV7Node); err == nil {
+		t.Fatal("V7Node expected error for node ID 1024")
+	}
+}
+
+func TestGeneratorV7Node_NoCrossNodeCollisions(t *testing.T) {
+	frozen := time.UnixMilli(1700000000000)
+	nodes := []uint16{0, 1, 2, 511, 512, 1023}
+	perNode := 5000 // more than the 4096 counter values in one millisecond
+
+	seen := make(map[string]uint16, len(nodes)*perNode)
+	for _, node := range nodes {
+		g := &Generator{now: func() time.Time { return frozen }}
+		prev := ""
+		for i := 0; i < perNode; i++ {
+			id, err := g.V7Node(node)
+			if err != nil {
+				t.Fatalf("V7Node error: %v", err)
+			}
+			if other, ok := seen[id]; ok {
+				t.Fatalf("collision between node %d and node %d: %s", node, other, id)
+			}
+			seen[id] = node
+			if id <= prev {
+				t.Fatalf("node %d IDs not increasing: %s <= %s", node, id, prev)
+			}
+			prev = id
+			if got, _ := NodeFromV7Node(id); got != node {
+				t.Fatalf("NodeFromV7Node(%s) = %d, want %d", id, got, node)
+			}
+		}
+	}
+}
+
+func TestNodeFromV7Node_Invalid(t *testing.T) {
+	if _, err := NodeFromV7Node("invalid"); err == nil {
+		t.Fatal("NodeFromV7Node expected error for invalid UUID")
+	}
+	if _, err := NodeFromV7Node(UuidV4()); err == nil {
+		t.Fatal("NodeFromV7Node expected error for non-v7 UUID")
+	}
+}
+
+// failingReader is a random source that always fails.
+type failingReader struct{}
+
+func (failingReader) Read([]byte) (int, error) {
+	return 0, errors.New("entropy source unavailable")
+}
+
+func TestGeneratorV4V7(t *testing.T) {
+	var g Generator
+	a, err := g.V4()
+	if err != nil {
+		t.Fatalf("V4 error: %v", err)
+	}
+	assertLenAndVersion(t, a, 32, '4', false)
+
+	b, err := g.V7(true)
+	if err != nil {
+		t.Fatalf("V7 error: %v", err)
+	}
+	assertLenAndVersion(t, b, 36, '7', true)
+}
+
+func TestGeneratorMustV4MustV7(t *testing.T) {
+	var g Generator
+	assertLenAndVersion(t, g.MustV4(), 32, '4', false)
+	assertLenAndVersion(t, g.MustV7(true), 36, '7', true)
+}
+
+func TestGeneratorMust_PanicsOnFailingReader(t *testing.T) {
+	g := &Generator{Reader: failingReader{}}
+	for name, fn := range map[string]func(){
+		"MustV4": func() { g.MustV4() },
+		"MustV7": func() { g.MustV7() },
+	} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Fatalf("%s did not panic with a failing reader", name)
+				}
+			}()
+			fn()
+		}()
+	}
+
+	if _, err := g.V4(); err == nil {
+		t.Fatal("V4 expected error with a failing reader")
+	}
+	if _, err := g.V7Node(1); err == nil {
+		t.Fatal("V7Node expected error with a failing reader")
+	}
+}
+
+func TestGeneratorV7ZeroRandA(t *testing.T) {
+	var g Generator
+	for i := 0; i < 100; i++ {
+		id, err := g.V7ZeroRandA()
+		if err != nil {
+			t.Fatalf("V7ZeroRandA error: %v", err)
+		}
+		assertLenAndVersion(t, id, 32, '7', false)
+		if id[13:16] != "000" {
+			t.Fatalf("V7ZeroRandA rand_a = %s, want 000; value=%s", id[13:16], id)
+		}
+		if !strings.ContainsRune("89ab", rune(id[16])) {
+			t.Fatalf("V7ZeroRandA variant nibble = %c; value=%s", id[16], id)
+		}
+	}
+
+	formatted, err := g.V7ZeroRandA(true)
+	if err != nil {
+		t.Fatalf("V7ZeroRandA error: %v", err)
+	}
+	assertLenAndVersion(t, formatted, 36, '7', true)
+}
+
+func TestGeneratorV6At(t *testing.T) {
+	var g Generator
+	at := time.Date(2012, 7, 4, 9, 30, 15, 123456700, time.UTC)
+
+	a, err := g.V6At(at)
+	if err != nil {
+		t.Fatalf("V6At error: %v", err)
+	}
+	assertLenAndVersion(t, a, 32, '6', false)
+	got, err := TimeFromV6(a)
+	if err != nil {
+		t.Fatalf("TimeFromV6 error: %v", err)
+	}
+	if !got.Equal(at) {
+		t.Fatalf("TimeFromV6 = %v, want %v", got, at)
+	}
+
+	b, err := g.V6At(at.Add(100 * time.Nanosecond))
+	if err != nil {
+		t.Fatalf("V6At error: %v", err)
+	}
+	if b <= a {
+		t.Fatalf("V6At with a later time must sort after: %s <= %s", b, a)
+	}
+
+	formatted, err := g.V6At(at, true)
+	if err != nil {
+		t.Fatalf("V6At error: %v", err)
+	}
+	assertLenAndVersion(t, formatted, 36, '6', true)
+}
+
+func TestGeneratorV6At_BeforeGregorianEpoch(t *testing.T) {
+	var g Generator
+	if _, err := g.V6At(time.Date(1500, 1, 1, 0, 0, 0, 0, time.UTC)); err == nil {
+		t.Fatal("V6At expected error for a time before 1582-10-15")
+	}
+}
+
+func TestGeneratorV7Epoch(t *testing.T) {
+	epoch := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	now := time.Date(2024, 2, 29, 13, 14, 15, 16_000_000, time.UTC)
+	g := &Generator{now: func() time.Time { return now }}
+	g.SetEpoch(epoch)
+
+	a, err := g.V7Epoch()
+	if err != nil {
+		t.Fatalf("V7Epoch error: %v", err)
+	}
+	assertLenAndVersion(t, a, 32, '7', false)
+	got, err := g.TimeFromV7Epoch(a)
+	if err != nil {
+		t.Fatalf("TimeFromV7Epoch error: %v", err)
+	}
+	if !got.Equal(now) {
+		t.Fatalf("TimeFromV7Epoch = %v, want %v", got, now)
+	}
+	if ms := now.Sub(epoch).Milliseconds(); a[:12] != fmt.Sprintf("%012x", ms) {
+		t.Fatalf("V7Epoch timestamp = %s, want %012x ms since the custom epoch", a[:12], ms)
+	}
+
+	now = now.Add(time.Millisecond)
+	b, err := g.V7Epoch(true)
+	if err != nil {
+		t.Fatalf("V7Epoch error: %v", err)
+	}
+	assertLenAndVersion(t, b, 36, '7', true)
+	if FastRemoveHyphens(b) <= a {
+		t.Fatalf("V7Epoch values must sort by time: %s <= %s", b, a)
+	}
+}
+
+func TestGeneratorV7Epoch_BeforeEpoch(t *testing.T) {
+	g := &Generator{now: func() time.Time { return time.Date(2019, 12, 31, 0, 0, 0, 0, time.UTC) }}
+	g.SetEpoch(time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC))
+	if _, err := g.V7Epoch(); err == nil {
+		t.Fatal("V7Epoch expected error for a time before the epoch")
+	}
+}
+
+func TestGeneratorV7Epoch_DefaultUnixEpoch(t *testing.T) {
+	var g Generator
+	id, err := g.V7Epoch()
+	if err != nil {
+		t.Fatalf("V7Epoch error: %v", err)
+	}
+	got, err := g.TimeFromV7Epoch(id)
+	if err != nil {
+		t.Fatalf("TimeFromV7Epoch error: %v", err)
+	}
+	if d := time.Since(got); d < 0 || d > time.Second {
+		t.Fatalf("TimeFromV7Epoch = %v, want close to now", got)
+	}
+}
+
+// zeroReader is a broken random source that only yields zero bytes.
+type zeroReader struct{ reads int }
+
+func (z *zeroReader) Read(p []byte) (int, error) {
+	z.reads++
+	clear(p)
+	return len(p), nil
+}
+
+func TestGeneratorV4Checked(t *testing.T) {
+	var g Generator
+	s, err := g.V4Checked(true)
+	if err != nil {
+		t.Fatalf("V4Checked error: %v", err)
+	}
+	assertLenAndVersion(t, s, 36, '4', true)
+}
+
+func TestGeneratorV4Checked_ZeroReader(t *testing.T) {
+	z := &zeroReader{}
+	g := &Generator{Reader: z}
+	if _, err := g.V4Checked(); err == nil {
+		t.Fatal("V4Checked accepted all-zero randomness")
+	}
+	if z.reads != v4CheckedAttempts {
+		t.Fatalf("V4Checked drew %d times, want %d", z.reads, v4CheckedAttempts)
+	}
+}
+
+func TestGeneratorV4Checked_RecoversAfterBadDraw(t *testing.T) {
+	bad := bytes.Repeat([]byte{0x5A}, 16)
+	g := &Generator{Reader: io.MultiReader(bytes.NewReader(bad), rand.Reader)}
+	s, err := g.V4Checked()
+	if err != nil {
+		t.Fatalf("V4Checked error: %v", err)
+	}
+	assertLenAndVersion(t, s, 32, '4', false)
+}
+
+func TestGeneratorV4Checked_FailingReader(t *testing.T) {
+	g := &Generator{Reader: failingReader{}}
+	if _, err := g.V4Checked(); err == nil {
+		t.Fatal("expected error with a failing reader")
+	}
+}
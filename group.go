@@ -0,0 +1,65 @@
+package uid
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"time"
+)
+
+// Layout of GroupedID: a 40-bit group hash (8 Base32 characters) followed by
+// 80 random bits (16 Base32 characters).
+const (
+	groupHashBytes   = 5
+	groupRandomBytes = 10
+	groupPrefixLen   = groupHashBytes * 8 / 5
+	groupedIDLen     = groupPrefixLen + groupRandomBytes*8/5
+)
+
+// GroupedID returns a 24-character uppercase Crockford Base32 ID whose first
+// 8 characters are derived from groupKey, so all IDs of a group share a
+// leading segment and can be range-scanned together. The remaining 16
+// characters are random.
+//
+// The prefix is the first 40 bits of SHA-256(groupKey); different keys
+// share a prefix with probability 2^-40 per pair, so scans should still
+// filter on the group key if that matters.
+//
+// Example: GroupedID("tenant-42") => "YWEKEGDJ" + 16 random characters
+//
+// Parameters:
+// - groupKey: the logical group, e.g. a tenant or parent record ID
+//
+// Returns:
+// - The 24-character ID
+func GroupedID(groupKey string) string {
+	sum := sha256.Sum256([]byte(groupKey))
+	var r [groupRandomBytes]byte
+	if err := readRandom(r[:]); err != nil {
+		// fallback
+		binary.BigEndian.PutUint64(r[2:], uint64(time.Now().UnixNano()))
+	}
+	return encodeBase(sum[:groupHashBytes], crockfordAlphabet, groupPrefixLen) +
+		encodeBase(r[:], crockfordAlphabet, groupedIDLen-groupPrefixLen)
+}
+
+// GroupPrefixOf returns the group segment of an ID produced by GroupedID,
+// usable as a scan prefix for the whole group.
+//
+// Example: GroupPrefixOf("YWEKEGDJG5F1T3Z0YVTWYEEW") => "YWEKEGDJ"
+//
+// Parameters:
+// - s: an ID produced by GroupedID
+//
+// Returns:
+// - The 8-character prefix, or "" if s is not a grouped ID
+func GroupPrefixOf(s string) string {
+	if len(s) != groupedIDLen {
+		return ""
+	}
+	for i := 0; i < len(s); i++ {
+		if crockfordDigit(s[i]) < 0 {
+			return ""
+		}
+	}
+	return s[:groupPrefixLen]
+}
@@ -0,0 +1,31 @@
+package uid
+
+import "testing"
+
+func TestGroupedID(t *testing.T) {
+	a := GroupedID("tenant-42")
+	b := GroupedID("tenant-42")
+	if len(a) != 24 || len(b) != 24 {
+		t.Fatalf("unexpected lengths: %q, %q", a, b)
+	}
+	if got := GroupPrefixOf(a); got != "YWEKEGDJ" {
+		t.Fatalf("GroupPrefixOf(%q) = %q, want %q", a, got, "YWEKEGDJ")
+	}
+	if GroupPrefixOf(a) != GroupPrefixOf(b) {
+		t.Fatalf("same group key, different prefixes: %q, %q", a, b)
+	}
+	if a[8:] == b[8:] {
+		t.Fatalf("same tail for two IDs: %q, %q", a, b)
+	}
+	if GroupPrefixOf(a) == GroupPrefixOf(GroupedID("tenant-43")) {
+		t.Fatal("different group keys share a prefix")
+	}
+}
+
+func TestGroupPrefixOf_Invalid(t *testing.T) {
+	for _, s := range []string{"", "ABC", GroupedID("g") + "0", "UUUUUUUUUUUUUUUUUUUUUUUU"} {
+		if got := GroupPrefixOf(s); got != "" {
+			t.Fatalf("GroupPrefixOf(%q) = %q, want empty", s, got)
+		}
+	}
+}
@@ -0,0 +1,46 @@
+package uid
+
+import "hash/fnv"
+
+// ColorSeed returns a stable 24-bit RGB value (0xRRGGBB) derived from a
+// UUID, so the same user always gets the same avatar color. The value is
+// an FNV-1a hash of the 16 UUID bytes and is meant for display only.
+//
+// Example: ColorSeed("550e8400-e29b-41d4-a716-446655440000") => 0xb550b2
+//
+// Parameters:
+// - s: a UUID in any form accepted by ParseWithFormat
+//
+// Returns:
+// - The color in the low 24 bits, or an error if s is invalid
+func ColorSeed(s string) (uint32, error) {
+	b, _, err := ParseWithFormat(s)
+	if err != nil {
+		return 0, err
+	}
+	h := fnv.New32a()
+	h.Write(b)
+	return h.Sum32() & 0xFFFFFF, nil
+}
+
+// ShardHash returns a uniformly distributed 64-bit shard key for a UUID:
+// the FNV-1a 64-bit hash of its 16 bytes. Unlike truncating the UUID, it
+// spreads time-ordered v7 IDs, whose leading bytes barely change, evenly
+// across shards. The value is stable across processes and releases.
+//
+// Example: ShardHash(id) % 16 => shard index 0-15
+//
+// Parameters:
+// - s: a UUID in any form accepted by ParseWithFormat
+//
+// Returns:
+// - The shard key, or an error if s is invalid
+func ShardHash(s string) (uint64, error) {
+	b, _, err := ParseWithFormat(s)
+	if err != nil {
+		return 0, err
+	}
+	h := fnv.New64a()
+	h.Write(b)
+	return h.Sum64(), nil
+}
@@ -0,0 +1,87 @@
+package uid
+
+import "testing"
+
+func TestColorSeed(t *testing.T) {
+	a, err := ColorSeed("550e8400-e29b-41d4-a716-446655440000")
+	if err != nil {
+		t.Fatalf("ColorSeed error: %v", err)
+	}
+	if a != 0xb550b2 {
+		t.Fatalf("ColorSeed = %06x, want b550b2", a)
+	}
+	b, err := ColorSeed("{550E8400-E29B-41D4-A716-446655440000}")
+	if err != nil {
+		t.Fatalf("ColorSeed error: %v", err)
+	}
+	if a != b {
+		t.Fatalf("ColorSeed must not depend on the textual form: %06x != %06x", a, b)
+	}
+
+	colors := make(map[uint32]bool)
+	for i := 0; i < 1000; i++ {
+		c, err := ColorSeed(UuidV4())
+		if err != nil {
+			t.Fatalf("ColorSeed error: %v", err)
+		}
+		if c > 0xFFFFFF {
+			t.Fatalf("ColorSeed = %x, exceeds 24 bits", c)
+		}
+		colors[c] = true
+	}
+	if len(colors) < 990 {
+		t.Fatalf("ColorSeed produced only %d distinct colors for 1000 UUIDs", len(colors))
+	}
+
+	if _, err := ColorSeed("invalid"); err == nil {
+		t.Fatal("ColorSeed expected error for invalid UUID")
+	}
+}
+
+func TestShardHash(t *testing.T) {
+	a, err := ShardHash("550e8400-e29b-41d4-a716-446655440000")
+	if err != nil {
+		t.Fatalf("ShardHash error: %v", err)
+	}
+	b, err := ShardHash("550E8400E29B41D4A716446655440000")
+	if err != nil {
+		t.Fatalf("ShardHash error: %v", err)
+	}
+	if a != b {
+		t.Fatalf("ShardHash must not depend on the textual form: %x != %x", a, b)
+	}
+	if _, err := ShardHash("nope"); err == nil {
+		t.Fatal("expected error for invalid input")
+	}
+}
+
+func TestShardHash_Distribution(t *testing.T) {
+	const shards = 16
+	const n = 16000
+	// consecutive v7s share their leading bytes, the worst case for
+	// truncation-based sharding
+	b := newV7()
+	sources := map[string]func() string{
+		"v7 generated": func() string { return UuidV7() },
+		"v7 consecutive": func() string {
+			incrementV7(b)
+			return canonicalString(b)
+		},
+		"v4": func() string { return UuidV4() },
+	}
+	for name, next := range sources {
+		var counts [shards]int
+		for i := 0; i < n; i++ {
+			h, err := ShardHash(next())
+			if err != nil {
+				t.Fatalf("ShardHash error: %v", err)
+			}
+			counts[h%shards]++
+		}
+		for shard, c := range counts {
+			if c < n/shards*3/4 || c > n/shards*5/4 {
+				t.Fatalf("%s: shard %d got %d of %d IDs, distribution %v", name, shard, c, n, counts)
+			}
+		}
+	}
+}
@@ -0,0 +1,195 @@
+package uid
+
+import (
+	"math"
+	"strings"
+	"time"
+)
+
+// uuidVersions lists every UUID version the package can generate; it backs
+// both SupportedVersions and Generate. Versions whose content depends on
+// caller input (name-based v3/v5, custom v8) have no standalone generator
+// and build their UUID from the arguments of Generate instead.
+var uuidVersions = []struct {
+	version   int
+	generate  func() []byte
+	fromInput func(args []string) (string, error)
+}{
+	{1, newV1, nil},
+	{3, nil, nameBased(UuidV3FromString)},
+	{4, newV4, nil},
+	{5, nil, nameBased(UuidV5FromString)},
+	{6, newV6, nil},
+	{7, newV7, nil},
+	{8, nil, customBytes},
+}
+
+// generatorFor returns the standalone generator of a UUID version, or nil
+// if the version needs caller input or is unknown.
+func generatorFor(version int) func() []byte {
+	for _, v := range uuidVersions {
+		if v.version == version {
+			return v.generate
+		}
+	}
+	return nil
+}
+
+// SupportedVersions returns the UUID versions the package can generate,
+// in ascending order.
+//
+// Example: [1 3 4 5 6 7 8]
+//
+// Parameters:
+// - None
+//
+// Returns:
+// - A new slice of version numbers
+func SupportedVersions() []int {
+	versions := make([]int, 0, len(uuidVersions))
+	for _, v := range uuidVersions {
+		versions = append(versions, v.version)
+	}
+	return versions
+}
+
+// SupportedFormats returns the textual UUID formats accepted by the parser.
+//
+// Example: [urn braced hyphenated bare]
+//
+// Parameters:
+// - None
+//
+// Returns:
+// - A new slice of format names (see FormatBare and friends)
+func SupportedFormats() []string {
+	formats := make([]string, 0, len(uuidFormats))
+	for _, f := range uuidFormats {
+		formats = append(formats, f.name)
+	}
+	return formats
+}
+
+// TimeResolution returns the effective resolution of the timestamp embedded
+// by the generator of the given kind.
+//
+// Kinds: "v1", "v6" (100ns), "v7" (1ms), "human", "nano" (100ns),
+// "micro" (1µs), "sec" (1s), "timestamp" (1s), "timestamp_micro" (1µs)
+// and "timestamp_nano" (1ns).
+//
+// Parameters:
+// - kind: the generator kind
+//
+// Returns:
+// - The timestamp resolution, or 0 for unknown kinds and kinds without a
+// timestamp (such as "v4")
+func TimeResolution(kind string) time.Duration {
+	switch kind {
+	case "v1", "v6":
+		return 100 * time.Nanosecond // Gregorian 100-ns intervals, see now100ns
+	case "v7":
+		return time.Millisecond
+	case "human":
+		return uidResolution(humanUidLength)
+	case "nano":
+		return uidResolution(nanoUidLength)
+	case "micro":
+		return uidResolution(microUidLength)
+	case "sec":
+		return uidResolution(secUidLength)
+	case "timestamp":
+		return time.Second
+	case "timestamp_micro":
+		return time.Microsecond
+	case "timestamp_nano":
+		return time.Nanosecond
+	}
+	return 0
+}
+
+// uidResolution returns the resolution of the uidTimeLayout prefix kept by a
+// time-prefixed ID of the given length.
+func uidResolution(length int) time.Duration {
+	layoutDigits := len(strings.ReplaceAll(uidTimeLayout, ".", ""))
+	kept := min(length, layoutDigits) - secUidLength // fractional digits kept
+	if kept <= 0 {
+		return time.Second
+	}
+	d := time.Second
+	for i := 0; i < kept; i++ {
+		d /= 10
+	}
+	return d
+}
+
+// EntropyBits returns the number of unpredictable bits per UUID of the
+// given version, as generated by this package.
+//
+//   - v4: 122 (all but the 4 version and 2 variant bits)
+//   - v7: 74 (rand_a and rand_b)
+//   - v1, v6: 0; the clock sequence and, without a hardware address, the
+//     random node are chosen once per process, so they add nothing per ID
+//   - v3, v5: 0; name-based UUIDs are deterministic
+//   - v8: 0; the layout is application-defined
+//
+// Parameters:
+// - version: the UUID version
+//
+// Returns:
+// - The entropy in bits, or 0 for unknown versions
+func EntropyBits(version int) int {
+	switch version {
+	case 4:
+		return 128 - 4 - 2
+	case 7:
+		return 128 - 48 - 4 - 2
+	}
+	return 0
+}
+
+// safeRateCollisionProbability is the per-tick collision probability
+// SafeRate accepts.
+const safeRateCollisionProbability = 1e-6
+
+// SafeRate returns a conservative maximum number of IDs per second for the
+// time-prefixed generators ("human", "nano", "micro", "sec") before
+// collisions become likely.
+//
+// Derivation: an ID of a given length keeps as many digits of the
+// 21-digit timestamp as fit (see TimeResolution) and fills the rest with
+// random digits. Within one timestamp tick, k IDs drawn from N = 10^digits
+// random suffixes collide with probability about k²/2N (birthday bound),
+// so k = sqrt(2·N·1e-6) keeps it below one in a million; at least one ID
+// per tick is always allowed. The rate is k times the ticks per second:
+//
+//   - human: 11 random digits, 100ns ticks => 447 per tick, 4,470,000,000/s
+//   - nano: 2 random digits, 100ns ticks => 1 per tick, 10,000,000/s
+//   - micro: no random digits, 1µs ticks => 1,000,000/s
+//   - sec: no random digits, 1s ticks => 1/s
+//
+// Parameters:
+// - kind: the generator kind
+//
+// Returns:
+// - The recommended maximum rate, or 0 for unknown kinds
+func SafeRate(kind string) (idsPerSecond int) {
+	var length int
+	switch kind {
+	case "human":
+		length = humanUidLength
+	case "nano":
+		length = nanoUidLength
+	case "micro":
+		length = microUidLength
+	case "sec":
+		length = secUidLength
+	default:
+		return 0
+	}
+	timeDigits := len(strings.ReplaceAll(uidTimeLayout, ".", ""))
+	digits := max(length-timeDigits, 0) // random digits after the timestamp
+	perTick := math.Floor(math.Sqrt(2 * math.Pow10(digits) * safeRateCollisionProbability))
+	perTick = max(perTick, 1)
+	ticksPerSecond := float64(time.Second / uidResolution(length))
+	return int(min(perTick*ticksPerSecond, math.MaxInt))
+}
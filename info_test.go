@@ -0,0 +1,109 @@
+package uid
+
+import (
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestSupportedVersions(t *testing.T) {
+	versions := SupportedVersions()
+	for _, want := range []int{1, 3, 4, 5, 6, 7, 8} {
+		if !slices.Contains(versions, want) {
+			t.Fatalf("SupportedVersions() = %v, missing %d", versions, want)
+		}
+	}
+	if !slices.IsSorted(versions) {
+		t.Fatalf("SupportedVersions() = %v, want ascending order", versions)
+	}
+}
+
+func TestSupportedVersions_Generators(t *testing.T) {
+	for _, v := range uuidVersions {
+		if v.generate == nil {
+			continue
+		}
+		b := v.generate()
+		if got := int(b[6] >> 4); got != v.version {
+			t.Fatalf("generator for v%d produced version %d", v.version, got)
+		}
+	}
+}
+
+func TestSupportedFormats(t *testing.T) {
+	formats := SupportedFormats()
+	for _, want := range []string{FormatBare, FormatHyphenated, FormatURN, FormatBraced} {
+		if !slices.Contains(formats, want) {
+			t.Fatalf("SupportedFormats() = %v, missing %q", formats, want)
+		}
+	}
+
+	for _, in := range []string{
+		"550e8400e29b41d4a716446655440000",
+		"550e8400-e29b-41d4-a716-446655440000",
+		"urn:uuid:550e8400-e29b-41d4-a716-446655440000",
+		"{550e8400-e29b-41d4-a716-446655440000}",
+	} {
+		_, format, err := ParseWithFormat(in)
+		if err != nil {
+			t.Fatalf("ParseWithFormat(%q) error: %v", in, err)
+		}
+		if !slices.Contains(formats, format) {
+			t.Fatalf("parsed format %q not listed in SupportedFormats()", format)
+		}
+	}
+}
+
+func TestTimeResolution(t *testing.T) {
+	cases := map[string]time.Duration{
+		"v1":              100 * time.Nanosecond,
+		"v6":              100 * time.Nanosecond,
+		"v7":              time.Millisecond,
+		"human":           100 * time.Nanosecond,
+		"nano":            100 * time.Nanosecond,
+		"micro":           time.Microsecond,
+		"sec":             time.Second,
+		"timestamp":       time.Second,
+		"timestamp_micro": time.Microsecond,
+		"timestamp_nano":  time.Nanosecond,
+		"v4":              0,
+		"unknown":         0,
+	}
+	for kind, want := range cases {
+		if got := TimeResolution(kind); got != want {
+			t.Fatalf("TimeResolution(%q) = %v, want %v", kind, got, want)
+		}
+	}
+}
+
+func TestEntropyBits(t *testing.T) {
+	cases := map[int]int{1: 0, 3: 0, 4: 122, 5: 0, 6: 0, 7: 74, 8: 0, 42: 0}
+	for version, want := range cases {
+		if got := EntropyBits(version); got != want {
+			t.Fatalf("EntropyBits(%d) = %d, want %d", version, got, want)
+		}
+	}
+}
+
+func TestSafeRate(t *testing.T) {
+	cases := map[string]int{
+		"human":   4_470_000_000,
+		"nano":    10_000_000,
+		"micro":   1_000_000,
+		"sec":     1,
+		"v4":      0,
+		"unknown": 0,
+	}
+	for kind, want := range cases {
+		if got := SafeRate(kind); got != want {
+			t.Fatalf("SafeRate(%q) = %d, want %d", kind, got, want)
+		}
+	}
+
+	// a rate can never beat one ID per tick of the embedded timestamp
+	for _, kind := range []string{"human", "nano", "micro", "sec"} {
+		if perTick := int(time.Second / TimeResolution(kind)); SafeRate(kind) < perTick {
+			t.Fatalf("SafeRate(%q) = %d, below one per tick (%d)", kind, SafeRate(kind), perTick)
+		}
+	}
+}
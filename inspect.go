@@ -0,0 +1,181 @@
+package uid
+
+import (
+	"encoding/binary"
+	"fmt"
+	"net"
+	"strings"
+	"time"
+)
+
+// plausibleSince is the earliest timestamp GuessVersion accepts as a real
+// generation time.
+var plausibleSince = time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC)
+
+// GuessVersion guesses the version of a UUID whose version nibble has been
+// lost, by checking which time-based layout (v1, v6 or v7) decodes to a
+// plausible timestamp.
+//
+// This is a heuristic: a layout is plausible when its timestamp lies
+// between 2000-01-01 and ten years from now. Among plausible layouts the one
+// closest to the current time wins. Random data occasionally decodes to a
+// plausible time, so the result must not be relied on for anything but
+// recovery and diagnostics.
+//
+// Parameters:
+// - b: the 16 UUID bytes
+//
+// Returns:
+// - The guessed version (1, 6 or 7), or 0 if no layout is plausible
+// - A confidence between 0 and 1: 1 divided by the number of plausible
+// layouts, or 0 when nothing was guessed
+func GuessVersion(b []byte) (int, float64) {
+	if len(b) != 16 {
+		return 0, 0
+	}
+	now := nowFunc()
+	until := now.AddDate(10, 0, 0)
+	candidates := []struct {
+		version int
+		t       time.Time
+	}{
+		{1, gregorianTime(v1Time(b))},
+		{6, gregorianTime(v6Time(b))},
+		{7, time.UnixMilli(int64(v7Millis(b)))},
+	}
+
+	best, plausible := 0, 0
+	var bestDistance time.Duration
+	for _, c := range candidates {
+		if c.t.Before(plausibleSince) || c.t.After(until) {
+			continue
+		}
+		plausible++
+		distance := now.Sub(c.t).Abs()
+		if best == 0 || distance < bestDistance {
+			best, bestDistance = c.version, distance
+		}
+	}
+	if plausible == 0 {
+		return 0, 0
+	}
+	return best, 1 / float64(plausible)
+}
+
+// versionNames describes each UUID version for Explain.
+var versionNames = map[int]string{
+	1: "time-based, Gregorian 100-ns timestamp and node",
+	2: "DCE security",
+	3: "name-based, MD5",
+	4: "random",
+	5: "name-based, SHA-1",
+	6: "time-ordered, Gregorian 100-ns timestamp and node",
+	7: "time-ordered, Unix millisecond timestamp",
+	8: "custom",
+}
+
+// Explain returns a multi-line, human-readable breakdown of a UUID's
+// fields: version, variant, timestamp, clock sequence, node, and which bits
+// are random.
+//
+// Example (v7):
+//
+//	UUID:      01890f5f-3d9c-7a0e-8a7b-6c5d4e3f2a10
+//	Version:   7 (time-ordered, Unix millisecond timestamp)
+//	Variant:   RFC4122
+//	Timestamp: 2023-07-01T02:54:07.26Z (bits 0-47)
+//	Random:    74 bits (rand_a bits 52-63, rand_b bits 66-127)
+//
+// Parameters:
+// - s: a UUID in any form accepted by ParseWithFormat
+//
+// Returns:
+// - The description, or an error if s is invalid
+func Explain(s string) (string, error) {
+	b, _, err := ParseWithFormat(s)
+	if err != nil {
+		return "", err
+	}
+	version := int(b[6] >> 4)
+	name, ok := versionNames[version]
+	if !ok {
+		name = "unknown"
+	}
+
+	var sb strings.Builder
+	line := func(label, format string, args ...any) {
+		fmt.Fprintf(&sb, "%-11s"+format+"\n", append([]any{label + ":"}, args...)...)
+	}
+	line("UUID", "%s", canonicalString(b))
+	line("Version", "%d (%s)", version, name)
+	line("Variant", "%s", variantOf(b))
+
+	switch version {
+	case 1, 6:
+		t := v1Time(b)
+		bits := "bits 0-31 low, 32-47 mid, 52-63 high"
+		if version == 6 {
+			t = v6Time(b)
+			bits = "bits 0-47 high, 52-63 low"
+		}
+		line("Timestamp", "%s (%s)", gregorianTime(t).Format(time.RFC3339Nano), bits)
+		line("Clock seq", "%d (bits 66-79)", binary.BigEndian.Uint16(b[8:10])&0x3FFF)
+		node := net.HardwareAddr(b[10:16]).String()
+		if b[10]&0x01 != 0 {
+			line("Node", "%s (bits 80-127, random: multicast bit set)", node)
+			line("Random", "47 bits (node)")
+		} else {
+			line("Node", "%s (bits 80-127, hardware address)", node)
+			line("Random", "none")
+		}
+	case 7:
+		t := time.UnixMilli(int64(v7Millis(b))).UTC()
+		line("Timestamp", "%s (bits 0-47)", t.Format(time.RFC3339Nano))
+		line("Random", "74 bits (rand_a bits 52-63, rand_b bits 66-127)")
+	case 4:
+		line("Random", "122 bits (all but version and variant)")
+	case 3, 5:
+		line("Random", "none (hash of namespace and name)")
+	case 8:
+		line("Random", "layout is application-defined")
+	}
+	return sb.String(), nil
+}
+
+// Variant classifies the variant bits (the top bits of byte 8) of a UUID.
+// Parsing accepts every variant, so Microsoft GUIDs and other non-RFC
+// values can be classified rather than rejected.
+//
+//   - 0xxx: "NCS" (reserved, NCS backward compatibility)
+//   - 10xx: "RFC4122" (the variant of RFC 4122 and RFC 9562)
+//   - 110x: "Microsoft" (reserved, Microsoft backward compatibility)
+//   - 111x: "Future" (reserved for future definition)
+//
+// Example: Variant("550e8400-e29b-41d4-c716-446655440000") => "Microsoft"
+//
+// Parameters:
+// - s: a UUID in any form accepted by ParseWithFormat
+//
+// Returns:
+// - "NCS", "RFC4122", "Microsoft" or "Future", or an error if s is invalid
+func Variant(s string) (string, error) {
+	b, _, err := ParseWithFormat(s)
+	if err != nil {
+		return "", err
+	}
+	return variantOf(b), nil
+}
+
+// variantOf classifies the variant bits in b[8].
+func variantOf(b []byte) string {
+	switch {
+	case b[8]&0x80 == 0x00:
+		return "NCS"
+	case b[8]&0xC0 == 0x80:
+		return "RFC4122"
+	case b[8]&0xE0 == 0xC0:
+		return "Microsoft"
+	default:
+		return "Future"
+	}
+}
@@ -0,0 +1,103 @@
+package uid
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestGuessVersion(t *testing.T) {
+	generators := map[int]func() []byte{1: newV1, 6: newV6, 7: newV7}
+	for version, generate := range generators {
+		for i := 0; i < 100; i++ {
+			b := generate()
+			b[6] &= 0x0F // strip the version nibble
+			got, confidence := GuessVersion(b)
+			if got != version {
+				t.Fatalf("GuessVersion(%x) = %d, want %d", b, got, version)
+			}
+			if confidence <= 0 || confidence > 1 {
+				t.Fatalf("GuessVersion(%x) confidence = %v, want (0, 1]", b, confidence)
+			}
+		}
+	}
+}
+
+func TestGuessVersion_NoTimestamp(t *testing.T) {
+	if v, c := GuessVersion(make([]byte, 16)); v != 0 || c != 0 {
+		t.Fatalf("GuessVersion(zero) = %d, %v; want 0, 0", v, c)
+	}
+	if v, c := GuessVersion([]byte{1, 2, 3}); v != 0 || c != 0 {
+		t.Fatalf("GuessVersion(short) = %d, %v; want 0, 0", v, c)
+	}
+}
+
+func TestExplain(t *testing.T) {
+	cases := map[string][]string{
+		UuidV7(): {"Version:   7", "Variant:   RFC4122", "Timestamp:", "Random:    74 bits"},
+		UuidV1(): {"Version:   1", "Variant:   RFC4122", "Timestamp:", "Clock seq:", "Node:", "Random:"},
+		UuidV4(): {"Version:   4", "Random:    122 bits"},
+	}
+	for id, labels := range cases {
+		out, err := Explain(id)
+		if err != nil {
+			t.Fatalf("Explain(%s) error: %v", id, err)
+		}
+		for _, label := range labels {
+			if !strings.Contains(out, label) {
+				t.Fatalf("Explain(%s) missing %q:\n%s", id, label, out)
+			}
+		}
+	}
+
+	if _, err := Explain("invalid"); err == nil {
+		t.Fatal("Explain expected error for invalid UUID")
+	}
+}
+
+func TestExplain_DocExample(t *testing.T) {
+	want := "UUID:      01890f5f-3d9c-7a0e-8a7b-6c5d4e3f2a10\n" +
+		"Version:   7 (time-ordered, Unix millisecond timestamp)\n" +
+		"Variant:   RFC4122\n" +
+		"Timestamp: 2023-07-01T02:54:07.26Z (bits 0-47)\n" +
+		"Random:    74 bits (rand_a bits 52-63, rand_b bits 66-127)\n"
+	got, err := Explain("01890f5f-3d9c-7a0e-8a7b-6c5d4e3f2a10")
+	if err != nil {
+		t.Fatalf("Explain error: %v", err)
+	}
+	if got != want {
+		t.Fatalf("Explain(doc example) =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestVariant(t *testing.T) {
+	want := map[byte]string{
+		0x0: "NCS", 0x1: "NCS", 0x2: "NCS", 0x3: "NCS",
+		0x4: "NCS", 0x5: "NCS", 0x6: "NCS", 0x7: "NCS",
+		0x8: "RFC4122", 0x9: "RFC4122", 0xa: "RFC4122", 0xb: "RFC4122",
+		0xc: "Microsoft", 0xd: "Microsoft",
+		0xe: "Future", 0xf: "Future",
+	}
+	for nibble, variant := range want {
+		s := fmt.Sprintf("550e8400-e29b-41d4-%x716-446655440000", nibble)
+		got, err := Variant(s)
+		if err != nil {
+			t.Fatalf("Variant(%q) error: %v", s, err)
+		}
+		if got != variant {
+			t.Fatalf("Variant(%q) = %q, want %q", s, got, variant)
+		}
+		if _, err := Parse(s); err != nil {
+			t.Fatalf("Parse(%q) rejected a %s UUID: %v", s, variant, err)
+		}
+		if err := Validate(s); (err == nil) != (variant == "RFC4122") {
+			t.Fatalf("Validate(%q) = %v", s, err)
+		} else if err != nil && !strings.Contains(err.Error(), variant) {
+			t.Fatalf("Validate(%q) error %q does not name the %s variant", s, err, variant)
+		}
+	}
+
+	if _, err := Variant("invalid"); err == nil {
+		t.Fatal("Variant expected error for invalid input")
+	}
+}
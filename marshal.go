@@ -0,0 +1,70 @@
+package uid
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// MarshalJSON implements json.Marshaler, encoding u as its canonical
+// lowercase hyphenated string.
+func (u UUID) MarshalJSON() ([]byte, error) {
+	b := make([]byte, 0, 38)
+	b = append(b, '"')
+	b = append(b, canonicalString(u[:])...)
+	return append(b, '"'), nil
+}
+
+// UnmarshalJSON implements json.Unmarshaler. It accepts a JSON string
+// holding a UUID in any form accepted by Parse, hyphenated or not, and
+// null, which yields the nil UUID.
+func (u *UUID) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		*u = UUID{}
+		return nil
+	}
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return fmt.Errorf("uid: UUID must be a JSON string: %w", err)
+	}
+	parsed, err := ParseUUID(s)
+	if err != nil {
+		return fmt.Errorf("uid: cannot unmarshal %q into UUID: %w", s, err)
+	}
+	*u = parsed
+	return nil
+}
+
+// MarshalText implements encoding.TextMarshaler, encoding u as its
+// canonical lowercase hyphenated string.
+func (u UUID) MarshalText() ([]byte, error) {
+	return []byte(canonicalString(u[:])), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler. It accepts a UUID in
+// any form accepted by Parse.
+func (u *UUID) UnmarshalText(text []byte) error {
+	parsed, err := ParseUUID(string(text))
+	if err != nil {
+		return fmt.Errorf("uid: cannot unmarshal %q into UUID: %w", text, err)
+	}
+	*u = parsed
+	return nil
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler, returning the raw 16
+// bytes: half the size of the text form.
+func (u UUID) MarshalBinary() ([]byte, error) {
+	b := make([]byte, 16)
+	copy(b, u[:])
+	return b, nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler. data must be
+// exactly 16 bytes.
+func (u *UUID) UnmarshalBinary(data []byte) error {
+	if len(data) != 16 {
+		return fmt.Errorf("uid: binary UUID must be 16 bytes, got %d", len(data))
+	}
+	copy(u[:], data)
+	return nil
+}
@@ -0,0 +1,186 @@
+package uid
+
+import (
+	"bytes"
+	"encoding/gob"
+	"encoding/json"
+	"encoding/xml"
+	"strings"
+	"testing"
+)
+
+func TestUUID_JSON(t *testing.T) {
+	u, _ := ParseUUID("550E8400E29B41D4A716446655440000")
+	data, err := json.Marshal(u)
+	if err != nil {
+		t.Fatalf("Marshal error: %v", err)
+	}
+	if string(data) != `"550e8400-e29b-41d4-a716-446655440000"` {
+		t.Fatalf("Marshal = %s", data)
+	}
+
+	for _, in := range []string{
+		`"550e8400-e29b-41d4-a716-446655440000"`,
+		`"550e8400e29b41d4a716446655440000"`,
+		`"550E8400-E29B-41D4-A716-446655440000"`,
+	} {
+		var back UUID
+		if err := json.Unmarshal([]byte(in), &back); err != nil {
+			t.Fatalf("Unmarshal(%s) error: %v", in, err)
+		}
+		if back != u {
+			t.Fatalf("Unmarshal(%s) = %v, want %v", in, back, u)
+		}
+	}
+}
+
+func TestUUID_JSONRoundTrip(t *testing.T) {
+	type record struct {
+		ID     UUID  `json:"id"`
+		Parent *UUID `json:"parent"`
+	}
+	parent := NewV4()
+	in := record{ID: NewV7(), Parent: &parent}
+	data, err := json.Marshal(in)
+	if err != nil {
+		t.Fatalf("Marshal error: %v", err)
+	}
+	var out record
+	if err := json.Unmarshal(data, &out); err != nil {
+		t.Fatalf("Unmarshal error: %v", err)
+	}
+	if out.ID != in.ID || out.Parent == nil || *out.Parent != parent {
+		t.Fatalf("round trip: got %+v want %+v", out, in)
+	}
+}
+
+func TestUUID_JSONNull(t *testing.T) {
+	u := NewV4()
+	if err := json.Unmarshal([]byte("null"), &u); err != nil {
+		t.Fatalf("Unmarshal(null) error: %v", err)
+	}
+	if !u.IsZero() {
+		t.Fatalf("Unmarshal(null) = %v, want the nil UUID", u)
+	}
+}
+
+func TestUUID_JSONInvalid(t *testing.T) {
+	for _, in := range []string{`"nope"`, `42`, `"550e8400-e29b-41d4-a716-44665544000g"`, `{}`} {
+		var u UUID
+		if err := json.Unmarshal([]byte(in), &u); err == nil {
+			t.Fatalf("Unmarshal(%s) expected error", in)
+		}
+	}
+}
+
+func TestUUID_Text(t *testing.T) {
+	u, _ := ParseUUID("550E8400E29B41D4A716446655440000")
+	text, err := u.MarshalText()
+	if err != nil {
+		t.Fatalf("MarshalText error: %v", err)
+	}
+	if string(text) != "550e8400-e29b-41d4-a716-446655440000" {
+		t.Fatalf("MarshalText = %s", text)
+	}
+	var back UUID
+	if err := back.UnmarshalText([]byte("550e8400e29b41d4a716446655440000")); err != nil {
+		t.Fatalf("UnmarshalText error: %v", err)
+	}
+	if back != u {
+		t.Fatalf("UnmarshalText = %v, want %v", back, u)
+	}
+	if err := back.UnmarshalText([]byte("nope")); err == nil {
+		t.Fatal("UnmarshalText expected error")
+	}
+}
+
+func TestUUID_TextMapKeysInJSON(t *testing.T) {
+	a, b := NewV7(), NewV7()
+	in := map[UUID]int{a: 1, b: 2}
+	data, err := json.Marshal(in)
+	if err != nil {
+		t.Fatalf("Marshal error: %v", err)
+	}
+	if !strings.Contains(string(data), `"`+a.StringFormatted()+`":1`) {
+		t.Fatalf("map keys not encoded as text: %s", data)
+	}
+	var out map[UUID]int
+	if err := json.Unmarshal(data, &out); err != nil {
+		t.Fatalf("Unmarshal error: %v", err)
+	}
+	if len(out) != 2 || out[a] != 1 || out[b] != 2 {
+		t.Fatalf("round trip: got %v want %v", out, in)
+	}
+}
+
+func TestUUID_TextXML(t *testing.T) {
+	type item struct {
+		ID    UUID `xml:"id,attr"`
+		Owner UUID `xml:"owner"`
+	}
+	in := item{ID: NewV7(), Owner: NewV4()}
+	data, err := xml.Marshal(in)
+	if err != nil {
+		t.Fatalf("xml.Marshal error: %v", err)
+	}
+	if !strings.Contains(string(data), `id="`+in.ID.StringFormatted()+`"`) {
+		t.Fatalf("xml.Marshal = %s", data)
+	}
+	var out item
+	if err := xml.Unmarshal(data, &out); err != nil {
+		t.Fatalf("xml.Unmarshal error: %v", err)
+	}
+	if out != in {
+		t.Fatalf("round trip: got %+v want %+v", out, in)
+	}
+	if err := xml.Unmarshal([]byte(`<item id="nope"></item>`), &out); err == nil {
+		t.Fatal("xml.Unmarshal expected error for an invalid UUID")
+	}
+}
+
+func TestUUID_Binary(t *testing.T) {
+	u := NewV7()
+	data, err := u.MarshalBinary()
+	if err != nil {
+		t.Fatalf("MarshalBinary error: %v", err)
+	}
+	if len(data) != 16 || !bytes.Equal(data, u[:]) {
+		t.Fatalf("MarshalBinary = %x, want %x", data, u[:])
+	}
+	data[0] ^= 0xFF
+	if data[0] == u[0] {
+		t.Fatal("MarshalBinary returned memory shared with the UUID")
+	}
+
+	var back UUID
+	if err := back.UnmarshalBinary(u[:]); err != nil {
+		t.Fatalf("UnmarshalBinary error: %v", err)
+	}
+	if back != u {
+		t.Fatalf("UnmarshalBinary = %v, want %v", back, u)
+	}
+	for _, bad := range [][]byte{nil, make([]byte, 15), make([]byte, 17), []byte(u.StringFormatted())} {
+		if err := back.UnmarshalBinary(bad); err == nil {
+			t.Fatalf("UnmarshalBinary(%d bytes) expected error", len(bad))
+		}
+	}
+}
+
+func TestUUID_BinaryGob(t *testing.T) {
+	type record struct {
+		ID   UUID
+		Name string
+	}
+	in := record{ID: NewV7(), Name: "x"}
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(in); err != nil {
+		t.Fatalf("gob encode error: %v", err)
+	}
+	var out record
+	if err := gob.NewDecoder(&buf).Decode(&out); err != nil {
+		t.Fatalf("gob decode error: %v", err)
+	}
+	if out != in {
+		t.Fatalf("gob round trip: got %+v want %+v", out, in)
+	}
+}
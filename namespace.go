@@ -0,0 +1,218 @@
+package uid
+
+import (
+	"crypto/sha1"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Well-known namespaces of RFC 4122 (appendix C) for UuidV3 and UuidV5, as
+// 16 raw bytes.
+//
+// Example: UuidV5(NamespaceDNS, []byte("www.example.com"), true) => "2ed6657d-e927-568b-95e1-2665a8aea6a2"
+const (
+	// NamespaceDNS is 6ba7b810-9dad-11d1-80b4-00c04fd430c8, for fully
+	// qualified domain names.
+	NamespaceDNS = "\x6b\xa7\xb8\x10\x9d\xad\x11\xd1\x80\xb4\x00\xc0\x4f\xd4\x30\xc8"
+	// NamespaceURL is 6ba7b811-9dad-11d1-80b4-00c04fd430c8, for URLs.
+	NamespaceURL = "\x6b\xa7\xb8\x11\x9d\xad\x11\xd1\x80\xb4\x00\xc0\x4f\xd4\x30\xc8"
+	// NamespaceOID is 6ba7b812-9dad-11d1-80b4-00c04fd430c8, for ISO object
+	// identifiers.
+	NamespaceOID = "\x6b\xa7\xb8\x12\x9d\xad\x11\xd1\x80\xb4\x00\xc0\x4f\xd4\x30\xc8"
+	// NamespaceX500 is 6ba7b814-9dad-11d1-80b4-00c04fd430c8, for X.500
+	// distinguished names (DER or text).
+	NamespaceX500 = "\x6b\xa7\xb8\x14\x9d\xad\x11\xd1\x80\xb4\x00\xc0\x4f\xd4\x30\xc8"
+)
+
+// UuidV3FromString is like UuidV3 but takes the namespace as a UUID string,
+// such as "6ba7b810-9dad-11d1-80b4-00c04fd430c8", instead of 16 raw bytes.
+//
+// Example: UuidV3FromString("6ba7b810-9dad-11d1-80b4-00c04fd430c8", []byte("www.example.com"), true) => "5df41881-3aed-3515-88a7-2f4a814cf09e"
+//
+// Parameters:
+// - namespaceUUID: the namespace in any form accepted by Parse
+// - data: the name to hash
+// - formatted: when true, include hyphens
+//
+// Returns:
+// - The UUID v3 as a string, or an error if namespaceUUID is invalid
+func UuidV3FromString(namespaceUUID string, data []byte, formatted ...bool) (string, error) {
+	ns, err := Parse(namespaceUUID)
+	if err != nil {
+		return "", fmt.Errorf("invalid namespace UUID: %w", err)
+	}
+	return UuidV3(string(ns), data, formatted...)
+}
+
+// UuidV5FromString is like UuidV5 but takes the namespace as a UUID string,
+// such as "6ba7b810-9dad-11d1-80b4-00c04fd430c8", instead of 16 raw bytes.
+//
+// Example: UuidV5FromString("6ba7b810-9dad-11d1-80b4-00c04fd430c8", []byte("www.example.com"), true) => "2ed6657d-e927-568b-95e1-2665a8aea6a2"
+//
+// Parameters:
+// - namespaceUUID: the namespace in any form accepted by Parse
+// - data: the name to hash
+// - formatted: when true, include hyphens
+//
+// Returns:
+// - The UUID v5 as a string, or an error if namespaceUUID is invalid
+func UuidV5FromString(namespaceUUID string, data []byte, formatted ...bool) (string, error) {
+	ns, err := Parse(namespaceUUID)
+	if err != nil {
+		return "", fmt.Errorf("invalid namespace UUID: %w", err)
+	}
+	return UuidV5(string(ns), data, formatted...)
+}
+
+// UuidV5Named returns a version 5 UUID for a user-entered name. The name is
+// trimmed of surrounding whitespace and lowercased before hashing, so
+// "  Alice " and "alice" map to the same UUID.
+//
+// Example (no hyphens): 21f7f8de80515b8986800195ef798b6a (length: 32)
+//
+// Parameters:
+// - namespace: a 16-byte UUID (as bytes) used as the namespace
+// - name: the name to normalize and hash
+// - allowEmpty: when false, a name that is empty after trimming is an error;
+// when true it hashes the namespace alone, like UuidV5 with empty data
+// - formatted: when true, include hyphens
+//
+// Returns:
+// - The UUID v5 as a string, or an error
+func UuidV5Named(namespace string, name string, allowEmpty bool, formatted ...bool) (string, error) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	if name == "" && !allowEmpty {
+		return "", errors.New("name must not be empty")
+	}
+	return UuidV5(namespace, []byte(name), formatted...)
+}
+
+// UuidFromReader returns the version 5 UUID of everything read from r,
+// streaming the content through SHA-1 instead of buffering it. The result
+// equals UuidV5(namespace, content).
+//
+// Example: UuidFromReader(ns, file) for content-addressed storage
+//
+// Parameters:
+// - namespace: a 16-byte UUID (as bytes) used as the namespace
+// - r: the content to hash, read until EOF
+// - formatted: when true, include hyphens
+//
+// Returns:
+// - The UUID v5 as a string, or an error from the namespace or the reader
+func UuidFromReader(namespace string, r io.Reader, formatted ...bool) (string, error) {
+	sum, err := newHashedFunc(sha1.New(), 5, namespace, func(w io.Writer) error {
+		_, err := io.Copy(w, r)
+		return err
+	})
+	if err != nil {
+		return "", err
+	}
+	withHyphens := len(formatted) > 0 && formatted[0]
+	return bytesToUUIDString(sum, withHyphens), nil
+}
+
+// UuidV5Fields returns a version 5 UUID (without hyphens) for a composite
+// key made of several ordered fields. Each field is prefixed with its
+// length before hashing, so field boundaries matter: ["a", "bc"] and
+// ["ab", "c"] produce different UUIDs, unlike naive concatenation.
+//
+// Example: UuidV5Fields(NamespaceOID, "tenant-42", "invoice", "2025-0001") => 2c9035e2986f581398c0d750a76d2ae7 (length: 32)
+//
+// Parameters:
+// - namespace: a 16-byte UUID (as bytes) used as the namespace
+// - fields: the ordered key fields
+//
+// Returns:
+// - The UUID v5 as a string, or an error
+func UuidV5Fields(namespace string, fields ...string) (string, error) {
+	sum, err := newHashedFunc(sha1.New(), 5, namespace, func(w io.Writer) error {
+		for _, f := range fields {
+			writeLengthPrefixed(w, []byte(f))
+		}
+		return nil
+	})
+	if err != nil {
+		return "", err
+	}
+	return bytesToUUIDString(sum, false), nil
+}
+
+// writeLengthPrefixed writes the 8-byte big-endian length of data followed
+// by data, making concatenated fields unambiguous.
+func writeLengthPrefixed(w io.Writer, data []byte) {
+	var n [8]byte
+	binary.BigEndian.PutUint64(n[:], uint64(len(data)))
+	w.Write(n[:])
+	w.Write(data)
+}
+
+// Child returns the version 5 UUID of childName within the namespace of
+// parentUUID, for deterministic hierarchies such as org → project →
+// resource. Children can be chained: the result is itself a valid parent.
+//
+// Example: Child("6ba7b810-9dad-11d1-80b4-00c04fd430c8", "python.org", true) => "886313e1-3b8a-5372-9b90-0c9aee199e5d"
+//
+// Parameters:
+// - parentUUID: the parent UUID in any form accepted by ParseWithFormat;
+// its 16 bytes are the namespace
+// - childName: the child's name, hashed as is
+// - formatted: when true, include hyphens
+//
+// Returns:
+// - The UUID v5 as a string, or an error if parentUUID is invalid
+func Child(parentUUID string, childName string, formatted ...bool) (string, error) {
+	ns, _, err := ParseWithFormat(parentUUID)
+	if err != nil {
+		return "", fmt.Errorf("invalid parent UUID: %w", err)
+	}
+	return UuidV5(string(ns), []byte(childName), formatted...)
+}
+
+// Namespace is a 16-byte UUID namespace for deriving deterministic version
+// 5 UUIDs from composite inputs, such as cache keys. Convert a UUID with
+// Namespace(u) or parse one with NewNamespace.
+type Namespace [16]byte
+
+// NewNamespace parses a UUID string into a Namespace.
+//
+// Example: NewNamespace("6ba7b810-9dad-11d1-80b4-00c04fd430c8")
+//
+// Parameters:
+// - namespaceUUID: the namespace in any form accepted by Parse
+//
+// Returns:
+// - The namespace, or an error if namespaceUUID is invalid
+func NewNamespace(namespaceUUID string) (Namespace, error) {
+	b, err := Parse(namespaceUUID)
+	if err != nil {
+		return Namespace{}, fmt.Errorf("invalid namespace: %w", err)
+	}
+	return Namespace(b), nil
+}
+
+// Derive returns the version 5 UUID (without hyphens) of parts within the
+// namespace. Parts are hashed in order, each prefixed with its length as
+// in UuidV5Fields, so [a, bc] and [ab, c] differ and so do different
+// orderings.
+//
+// Example: Namespace([]byte(NamespaceURL)).Derive([]byte("user"), []byte("42")) => 3401cc235cb459c1bfd5ac240b9a5e6d (length: 32)
+//
+// Parameters:
+// - parts: the ordered key parts
+//
+// Returns:
+// - The UUID v5 as a string
+func (ns Namespace) Derive(parts ...[]byte) string {
+	// a Namespace is always 16 bytes, so hashing cannot fail
+	sum, _ := newHashedFunc(sha1.New(), 5, string(ns[:]), func(w io.Writer) error {
+		for _, p := range parts {
+			writeLengthPrefixed(w, p)
+		}
+		return nil
+	})
+	return bytesToUUIDString(sum, false)
+}
@@ -0,0 +1,292 @@
+package uid
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+	"testing/iotest"
+)
+
+var testNamespace = string([]byte{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16})
+
+func TestUuidV5Named(t *testing.T) {
+	want, err := UuidV5(testNamespace, []byte("alice"))
+	if err != nil {
+		t.Fatalf("UuidV5 error: %v", err)
+	}
+	for _, name := range []string{"alice", "Alice", "  ALICE\t", "aLiCe\n"} {
+		got, err := UuidV5Named(testNamespace, name, false)
+		if err != nil {
+			t.Fatalf("UuidV5Named(%q) error: %v", name, err)
+		}
+		if got != want {
+			t.Fatalf("UuidV5Named(%q) = %s, want %s", name, got, want)
+		}
+	}
+
+	formatted, err := UuidV5Named(testNamespace, "Alice", false, true)
+	if err != nil {
+		t.Fatalf("UuidV5Named formatted error: %v", err)
+	}
+	assertLenAndVersion(t, formatted, 36, '5', true)
+}
+
+func TestUuidV5Named_Empty(t *testing.T) {
+	for _, name := range []string{"", "   ", "\t\n"} {
+		if _, err := UuidV5Named(testNamespace, name, false); err == nil {
+			t.Fatalf("UuidV5Named(%q) expected error when empty names are rejected", name)
+		}
+	}
+
+	want, err := UuidV5(testNamespace, nil)
+	if err != nil {
+		t.Fatalf("UuidV5 error: %v", err)
+	}
+	got, err := UuidV5Named(testNamespace, "  ", true)
+	if err != nil {
+		t.Fatalf("UuidV5Named error: %v", err)
+	}
+	if got != want {
+		t.Fatalf("UuidV5Named empty = %s, want namespace-only %s", got, want)
+	}
+}
+
+func TestUuidV5Named_InvalidNamespace(t *testing.T) {
+	if _, err := UuidV5Named("short", "alice", false); err == nil {
+		t.Fatal("UuidV5Named expected error for invalid namespace length")
+	}
+}
+
+func TestUuidFromReader(t *testing.T) {
+	content := bytes.Repeat([]byte("content-addressed asset "), 10000)
+	want, err := UuidV5(testNamespace, content)
+	if err != nil {
+		t.Fatalf("UuidV5 error: %v", err)
+	}
+	got, err := UuidFromReader(testNamespace, iotest.OneByteReader(bytes.NewReader(content)))
+	if err != nil {
+		t.Fatalf("UuidFromReader error: %v", err)
+	}
+	if got != want {
+		t.Fatalf("UuidFromReader = %s, want %s", got, want)
+	}
+
+	formatted, err := UuidFromReader(testNamespace, bytes.NewReader(content), true)
+	if err != nil {
+		t.Fatalf("UuidFromReader error: %v", err)
+	}
+	assertLenAndVersion(t, formatted, 36, '5', true)
+}
+
+func TestUuidFromReader_Errors(t *testing.T) {
+	if _, err := UuidFromReader("short", bytes.NewReader(nil)); err == nil {
+		t.Fatal("UuidFromReader expected error for invalid namespace length")
+	}
+	if _, err := UuidFromReader(testNamespace, iotest.ErrReader(errors.New("read failed"))); err == nil {
+		t.Fatal("UuidFromReader expected error from the reader")
+	}
+}
+
+func TestUuidV5Fields(t *testing.T) {
+	a, err := UuidV5Fields(testNamespace, "a", "bc")
+	if err != nil {
+		t.Fatalf("UuidV5Fields error: %v", err)
+	}
+	assertLenAndVersion(t, a, 32, '5', false)
+
+	b, err := UuidV5Fields(testNamespace, "ab", "c")
+	if err != nil {
+		t.Fatalf("UuidV5Fields error: %v", err)
+	}
+	if a == b {
+		t.Fatalf("UuidV5Fields must distinguish field boundaries: %s", a)
+	}
+
+	// naive concatenation cannot tell the two keys apart
+	naiveA, _ := UuidV5(testNamespace, []byte("a"+"bc"))
+	naiveB, _ := UuidV5(testNamespace, []byte("ab"+"c"))
+	if naiveA != naiveB {
+		t.Fatal("expected naive concatenation to be ambiguous")
+	}
+
+	again, _ := UuidV5Fields(testNamespace, "a", "bc")
+	if again != a {
+		t.Fatalf("UuidV5Fields not deterministic: %s != %s", again, a)
+	}
+	empty1, _ := UuidV5Fields(testNamespace, "", "x")
+	empty2, _ := UuidV5Fields(testNamespace, "x", "")
+	if empty1 == empty2 {
+		t.Fatal("UuidV5Fields must distinguish the position of empty fields")
+	}
+
+	if _, err := UuidV5Fields("short", "a"); err == nil {
+		t.Fatal("UuidV5Fields expected error for invalid namespace length")
+	}
+
+	// the documented example
+	got, err := UuidV5Fields(NamespaceOID, "tenant-42", "invoice", "2025-0001")
+	if err != nil || got != "2c9035e2986f581398c0d750a76d2ae7" {
+		t.Fatalf("UuidV5Fields(example) = %q, %v", got, err)
+	}
+}
+
+func TestChild(t *testing.T) {
+	got, err := Child("6ba7b810-9dad-11d1-80b4-00c04fd430c8", "python.org", true)
+	if err != nil {
+		t.Fatalf("Child error: %v", err)
+	}
+	if want := "886313e1-3b8a-5372-9b90-0c9aee199e5d"; got != want {
+		t.Fatalf("Child = %q, want %q", got, want)
+	}
+}
+
+func TestChild_Chaining(t *testing.T) {
+	const root = "550e8400-e29b-41d4-a716-446655440000"
+	chain := func(formatted bool) string {
+		p, err := Child(root, "p", formatted)
+		if err != nil {
+			t.Fatalf("Child(root) error: %v", err)
+		}
+		r, err := Child(p, "r", true)
+		if err != nil {
+			t.Fatalf("Child(%q) error: %v", p, err)
+		}
+		return r
+	}
+	first := chain(true)
+	if first != "ea246130-57ee-5402-8784-fa45abab3497" {
+		t.Fatalf("chained Child = %q", first)
+	}
+	if again := chain(false); again != first {
+		t.Fatalf("chaining not stable across parent forms: %q vs %q", again, first)
+	}
+	other, _ := Child(root, "q", true)
+	if sib, _ := Child(other, "r", true); sib == first {
+		t.Fatal("different parents produced the same child")
+	}
+}
+
+func TestChild_InvalidParent(t *testing.T) {
+	if _, err := Child("not-a-uuid", "p"); err == nil {
+		t.Fatal("expected error for invalid parent")
+	}
+}
+
+func TestNamespaceConstants(t *testing.T) {
+	cases := map[string]string{
+		NamespaceDNS:  "6ba7b810-9dad-11d1-80b4-00c04fd430c8",
+		NamespaceURL:  "6ba7b811-9dad-11d1-80b4-00c04fd430c8",
+		NamespaceOID:  "6ba7b812-9dad-11d1-80b4-00c04fd430c8",
+		NamespaceX500: "6ba7b814-9dad-11d1-80b4-00c04fd430c8",
+	}
+	for ns, want := range cases {
+		if got := canonicalString([]byte(ns)); got != want {
+			t.Fatalf("namespace = %q, want %q", got, want)
+		}
+	}
+}
+
+func TestNamespaceConstants_KnownValues(t *testing.T) {
+	cases := []struct {
+		gen  func(string, []byte, ...bool) (string, error)
+		ns   string
+		name string
+		want string
+	}{
+		{UuidV5, NamespaceDNS, "www.example.com", "2ed6657d-e927-568b-95e1-2665a8aea6a2"},
+		{UuidV3, NamespaceDNS, "www.example.com", "5df41881-3aed-3515-88a7-2f4a814cf09e"},
+		{UuidV5, NamespaceURL, "https://example.com", "4fd35a71-71ef-5a55-a9d9-aa75c889a6d0"},
+	}
+	for _, c := range cases {
+		got, err := c.gen(c.ns, []byte(c.name), true)
+		if err != nil {
+			t.Fatalf("generate(%q) error: %v", c.name, err)
+		}
+		if got != c.want {
+			t.Fatalf("generate(%q) = %q, want %q", c.name, got, c.want)
+		}
+	}
+}
+
+func TestUuidFromString(t *testing.T) {
+	const ns = "6ba7b810-9dad-11d1-80b4-00c04fd430c8"
+	for _, nsForm := range []string{ns, "6BA7B8109DAD11D180B400C04FD430C8", "{" + ns + "}"} {
+		v3, err := UuidV3FromString(nsForm, []byte("www.example.com"), true)
+		if err != nil {
+			t.Fatalf("UuidV3FromString(%q) error: %v", nsForm, err)
+		}
+		if v3 != "5df41881-3aed-3515-88a7-2f4a814cf09e" {
+			t.Fatalf("UuidV3FromString(%q) = %q", nsForm, v3)
+		}
+		v5, err := UuidV5FromString(nsForm, []byte("www.example.com"), true)
+		if err != nil {
+			t.Fatalf("UuidV5FromString(%q) error: %v", nsForm, err)
+		}
+		if v5 != "2ed6657d-e927-568b-95e1-2665a8aea6a2" {
+			t.Fatalf("UuidV5FromString(%q) = %q", nsForm, v5)
+		}
+	}
+	v5, _ := UuidV5FromString(ns, []byte("x"))
+	direct, _ := UuidV5(NamespaceDNS, []byte("x"))
+	if v5 != direct {
+		t.Fatalf("UuidV5FromString = %q, UuidV5 = %q", v5, direct)
+	}
+}
+
+func TestUuidFromString_InvalidNamespace(t *testing.T) {
+	for _, ns := range []string{"", NamespaceDNS, "6ba7b810-9dad-11d1-80b4-00c04fd430cz"} {
+		if _, err := UuidV3FromString(ns, []byte("x")); err == nil || !strings.Contains(err.Error(), "namespace") {
+			t.Fatalf("UuidV3FromString(%q) error = %v", ns, err)
+		}
+		if _, err := UuidV5FromString(ns, []byte("x")); err == nil || !strings.Contains(err.Error(), "namespace") {
+			t.Fatalf("UuidV5FromString(%q) error = %v", ns, err)
+		}
+	}
+}
+
+func TestNamespace_Derive(t *testing.T) {
+	ns := Namespace([]byte(NamespaceURL))
+	got := ns.Derive([]byte("user"), []byte("42"))
+	if got != "3401cc235cb459c1bfd5ac240b9a5e6d" {
+		t.Fatalf("Derive = %q", got)
+	}
+	assertLenAndVersion(t, got, 32, '5', false)
+	if again := ns.Derive([]byte("user"), []byte("42")); again != got {
+		t.Fatalf("Derive not deterministic: %q then %q", got, again)
+	}
+
+	fields, _ := UuidV5Fields(NamespaceURL, "user", "42")
+	if fields != got {
+		t.Fatalf("Derive = %q, UuidV5Fields = %q", got, fields)
+	}
+
+	distinct := []string{
+		got,
+		ns.Derive([]byte("42"), []byte("user")),
+		ns.Derive([]byte("user42")),
+		ns.Derive([]byte("use"), []byte("r42")),
+		ns.Derive(),
+		Namespace([]byte(NamespaceDNS)).Derive([]byte("user"), []byte("42")),
+	}
+	seen := map[string]bool{}
+	for _, id := range distinct {
+		if seen[id] {
+			t.Fatalf("Derive collision: %q in %q", id, distinct)
+		}
+		seen[id] = true
+	}
+}
+
+func TestNewNamespace(t *testing.T) {
+	ns, err := NewNamespace("6ba7b811-9dad-11d1-80b4-00c04fd430c8")
+	if err != nil {
+		t.Fatalf("NewNamespace error: %v", err)
+	}
+	if ns != Namespace([]byte(NamespaceURL)) {
+		t.Fatalf("NewNamespace = %x, want NamespaceURL", ns)
+	}
+	if _, err := NewNamespace("not-a-uuid"); err == nil || !strings.Contains(err.Error(), "namespace") {
+		t.Fatalf("NewNamespace error = %v, want a namespace error", err)
+	}
+}
@@ -0,0 +1,123 @@
+package uid
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"sync/atomic"
+)
+
+// clockSeqWraps counts how often the 14-bit clock sequence wrapped around.
+var clockSeqWraps atomic.Uint64
+
+// ClockSeqWraps returns how many times the v1/v6 clock sequence has wrapped
+// from 16383 back to 0 since the process started.
+//
+// The clock sequence is bumped whenever a UUID is requested without the
+// 100-ns clock advancing, and the bumps accumulate over the life of the
+// process. Because the initial sequence is random, the first wrap can come
+// after a single bump; each later wrap takes another 16384 bumps, possibly
+// spread over many clock ticks. A wrap alone is harmless: duplicates are
+// only possible when more than 16384 UUIDs are generated within a single
+// tick, reusing a sequence value. A quickly growing counter signals that
+// the load is approaching what v1/v6 can safely serve.
+//
+// Parameters:
+// - None
+//
+// Returns:
+// - The number of wraps
+func ClockSeqWraps() uint64 {
+	return clockSeqWraps.Load()
+}
+
+// SetNodeID sets the 6-byte node ID embedded in every v1 and v6 UUID,
+// replacing the detected hardware address or random node. Use it for a
+// stable, explicitly configured node in containers, where hardware
+// addresses change across restarts.
+//
+// Call it before the first v1/v6 generation. Called later, it re-seeds: the
+// clock sequence is drawn again whenever the node changes (RFC 4122,
+// section 4.1.5), so earlier and later UUIDs cannot collide.
+//
+// Example: SetNodeID([]byte{0x02, 0x42, 0xac, 0x11, 0x00, 0x02})
+//
+// Parameters:
+// - node: exactly 6 bytes
+//
+// Returns:
+// - An error if node is not 6 bytes long
+func SetNodeID(node []byte) error {
+	if len(node) != 6 {
+		return fmt.Errorf("node ID must be 6 bytes, got %d", len(node))
+	}
+	onceInit.Do(initState)
+	mu.Lock()
+	defer mu.Unlock()
+	if bytes.Equal(nodeIDData[:], node) {
+		return nil
+	}
+	copy(nodeIDData[:], node)
+	var b [2]byte
+	if err := readRandom(b[:]); err == nil {
+		clockSeq = binary.BigEndian.Uint16(b[:]) & 0x3FFF
+	} else {
+		clockSeq = (clockSeq + 1) & 0x3FFF
+	}
+	return nil
+}
+
+// NodeID returns a copy of the node ID embedded in v1 and v6 UUIDs: the
+// value set by SetNodeID, else the first 6-byte hardware address, else a
+// random node with the multicast bit set. Useful when investigating
+// duplicate-UUID reports.
+//
+// Returns:
+// - The 6-byte node ID; modifying it does not affect the package
+func NodeID() []byte {
+	node := currentNodeID()
+	return node[:]
+}
+
+// ClockSequence returns the current 14-bit v1/v6 clock sequence. It changes
+// whenever UUIDs are requested faster than the clock advances, so it is a
+// snapshot.
+//
+// Returns:
+// - The clock sequence, 0 to 0x3FFF
+func ClockSequence() uint16 {
+	onceInit.Do(initState)
+	mu.Lock()
+	defer mu.Unlock()
+	return clockSeq
+}
+
+// randomNodeOnly is set by SetRandomNodeID so that the hardware address is
+// never read.
+var randomNodeOnly atomic.Bool
+
+// systemNodeIDAllowed is systemNodeID unless SetRandomNodeID was called.
+func systemNodeIDAllowed() ([]byte, bool) {
+	if randomNodeOnly.Load() {
+		return nil, false
+	}
+	return systemNodeID()
+}
+
+// SetRandomNodeID installs a fresh random node ID with the multicast bit set
+// (RFC 4122, section 4.5), so v1 and v6 UUIDs no longer expose the
+// machine's MAC address. Called before the first v1/v6 generation, the
+// hardware address is never even read. Each call draws a new node, so two
+// processes end up with different nodes.
+//
+// Returns:
+// - An error if the random source fails
+func SetRandomNodeID() error {
+	var node [6]byte
+	if err := readRandom(node[:]); err != nil {
+		return err
+	}
+	node[0] |= 0x01 // multicast bit
+	randomNodeOnly.Store(true)
+	return SetNodeID(node[:])
+}
@@ -0,0 +1,142 @@
+package uid
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+)
+
+func TestClockSeqWraps(t *testing.T) {
+	useSteppingClock(t, 0) // frozen clock: every call reuses the timestamp
+
+	before := ClockSeqWraps()
+	for i := 0; i <= 0x3FFF+1; i++ {
+		newV1()
+	}
+	if after := ClockSeqWraps(); after <= before {
+		t.Fatalf("ClockSeqWraps = %d, want more than %d after exhausting the sequence", after, before)
+	}
+}
+
+// useNodeID sets the package node ID for the duration of the test.
+func useNodeID(t *testing.T, node []byte) {
+	t.Helper()
+	prev := currentNodeID()
+	if err := SetNodeID(node); err != nil {
+		t.Fatalf("SetNodeID error: %v", err)
+	}
+	t.Cleanup(func() { SetNodeID(prev[:]) })
+}
+
+func TestSetNodeID(t *testing.T) {
+	useNodeID(t, []byte{0x02, 0x42, 0xac, 0x11, 0x00, 0x02})
+
+	if id := UuidV1(); id[20:] != "0242ac110002" {
+		t.Fatalf("UuidV1() = %q, want node 0242ac110002", id)
+	}
+	if id := UuidV6(true); id[24:] != "0242ac110002" {
+		t.Fatalf("UuidV6() = %q, want node 0242ac110002", id)
+	}
+}
+
+func TestSetNodeID_ReseedsClockSequence(t *testing.T) {
+	useSteppingClock(t, 0)
+	useFixedReader(t, []byte{0x12, 0x34})
+	useNodeID(t, []byte{1, 2, 3, 4, 5, 6})
+
+	if err := SetNodeID([]byte{6, 5, 4, 3, 2, 1}); err != nil {
+		t.Fatalf("SetNodeID error: %v", err)
+	}
+	// the fixed reader yields 0x1234; 0x9234 once the variant bits are set
+	if id := UuidV1(true); id[19:23] != "9234" {
+		t.Fatalf("UuidV1() = %q, want clock sequence 1234", id)
+	}
+}
+
+func TestSetNodeID_Invalid(t *testing.T) {
+	for _, n := range [][]byte{nil, make([]byte, 5), make([]byte, 7)} {
+		if err := SetNodeID(n); err == nil {
+			t.Fatalf("SetNodeID(%x) expected error", n)
+		}
+	}
+}
+
+func TestNodeID(t *testing.T) {
+	node := NodeID()
+	if len(node) != 6 {
+		t.Fatalf("NodeID() length = %d, want 6", len(node))
+	}
+	if hw, ok := systemNodeID(); ok {
+		if !bytes.Equal(node, hw) {
+			t.Fatalf("NodeID() = %x, want hardware address %x", node, hw)
+		}
+	} else if node[0]&0x01 == 0 {
+		t.Fatalf("NodeID() = %x: random node must have the multicast bit set", node)
+	}
+
+	node[0] ^= 0xFF
+	if bytes.Equal(NodeID(), node) {
+		t.Fatal("NodeID() must return a copy")
+	}
+}
+
+func TestClockSequence(t *testing.T) {
+	useSteppingClock(t, 0)
+	UuidV1()
+	cs := ClockSequence()
+	if cs > 0x3FFF {
+		t.Fatalf("ClockSequence() = %#x exceeds 14 bits", cs)
+	}
+	id := UuidV1(true) // frozen clock: the sequence is bumped
+	if got := ClockSequence(); got != (cs+1)&0x3FFF {
+		t.Fatalf("ClockSequence() = %#x, want %#x", got, (cs+1)&0x3FFF)
+	}
+	if want := fmt.Sprintf("%04x", 0x8000|(cs+1)&0x3FFF); id[19:23] != want {
+		t.Fatalf("UuidV1() = %q, want clock sequence field %s", id, want)
+	}
+}
+
+func TestSetRandomNodeID(t *testing.T) {
+	prev := currentNodeID()
+	t.Cleanup(func() {
+		randomNodeOnly.Store(false)
+		SetNodeID(prev[:])
+	})
+
+	if err := SetRandomNodeID(); err != nil {
+		t.Fatalf("SetRandomNodeID error: %v", err)
+	}
+	first := NodeID()
+	if first[0]&0x01 == 0 {
+		t.Fatalf("NodeID() = %x: multicast bit not set", first)
+	}
+	if hw, ok := systemNodeID(); ok && bytes.Equal(first, hw) {
+		t.Fatalf("NodeID() = %x is the hardware address", first)
+	}
+	if id := UuidV1(); id[20:] != fmt.Sprintf("%x", first) {
+		t.Fatalf("UuidV1() = %q, want node %x", id, first)
+	}
+	if _, ok := systemNodeIDAllowed(); ok {
+		t.Fatal("hardware address still consulted after SetRandomNodeID")
+	}
+
+	// a second process draws its own node
+	if err := SetRandomNodeID(); err != nil {
+		t.Fatalf("SetRandomNodeID error: %v", err)
+	}
+	if second := NodeID(); bytes.Equal(first, second) {
+		t.Fatalf("two random nodes are equal: %x", first)
+	}
+}
+
+func TestSetRandomNodeID_ReaderFails(t *testing.T) {
+	prev := Reader
+	Reader = failingReader{}
+	t.Cleanup(func() { Reader = prev })
+	if err := SetRandomNodeID(); err == nil {
+		t.Fatal("SetRandomNodeID expected error from a failing reader")
+	}
+	if randomNodeOnly.Load() {
+		t.Fatal("privacy mode enabled despite the error")
+	}
+}
@@ -0,0 +1,83 @@
+package uid
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"hash"
+)
+
+// obfuscateRounds is the number of Feistel rounds applied by Obfuscate.
+const obfuscateRounds = 4
+
+// Obfuscate scrambles the 128 bits of a UUID with a keyed Feistel network,
+// so sequential IDs such as consecutive v7s look unrelated in public URLs.
+// Deobfuscate with the same key restores the original exactly.
+//
+// This is obfuscation, not encryption: it hides ordering from casual
+// observers but is not a substitute for access control.
+//
+// Example: Obfuscate([]byte("secret"), "01890a5d-ac96-774b-bcce-b302099a8057") => "134ae10a-46fb-ed90-7dc9-2a04071a266d"
+//
+// Parameters:
+// - key: the secret key, must not be empty
+// - s: a UUID in any form accepted by ParseWithFormat
+//
+// Returns:
+// - The obfuscated value as a lowercase hyphenated UUID-shaped string
+// - An error if key is empty or s is invalid
+func Obfuscate(key []byte, s string) (string, error) {
+	return feistel(key, s, false)
+}
+
+// Deobfuscate reverses Obfuscate.
+//
+// Example: Deobfuscate(key, Obfuscate(key, id)) => id
+//
+// Parameters:
+// - key: the key passed to Obfuscate
+// - s: an obfuscated value
+//
+// Returns:
+// - The original UUID in canonical form
+// - An error if key is empty or s is invalid
+func Deobfuscate(key []byte, s string) (string, error) {
+	return feistel(key, s, true)
+}
+
+// feistel runs the Feistel network over the two 64-bit halves of s,
+// forwards or in reverse.
+func feistel(key []byte, s string, reverse bool) (string, error) {
+	if len(key) == 0 {
+		return "", errors.New("obfuscation key must not be empty")
+	}
+	b, _, err := ParseWithFormat(s)
+	if err != nil {
+		return "", err
+	}
+	l := binary.BigEndian.Uint64(b[:8])
+	r := binary.BigEndian.Uint64(b[8:])
+	mac := hmac.New(sha256.New, key)
+	for i := 0; i < obfuscateRounds; i++ {
+		if !reverse {
+			l, r = r, l^feistelRound(mac, byte(i), r)
+		} else {
+			l, r = r^feistelRound(mac, byte(obfuscateRounds-1-i), l), l
+		}
+	}
+	binary.BigEndian.PutUint64(b[:8], l)
+	binary.BigEndian.PutUint64(b[8:], r)
+	return canonicalString(b), nil
+}
+
+// feistelRound is the round function: the first 64 bits of
+// HMAC-SHA256(key, round || half).
+func feistelRound(mac hash.Hash, round byte, half uint64) uint64 {
+	var in [9]byte
+	in[0] = round
+	binary.BigEndian.PutUint64(in[1:], half)
+	mac.Reset()
+	mac.Write(in[:])
+	return binary.BigEndian.Uint64(mac.Sum(nil))
+}
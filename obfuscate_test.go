@@ -0,0 +1,82 @@
+package uid
+
+import (
+	"math/bits"
+	"strings"
+	"testing"
+)
+
+func TestObfuscate_RoundTrip(t *testing.T) {
+	key := []byte("secret")
+	for i := 0; i < 100; i++ {
+		id := UuidV7(true)
+		o, err := Obfuscate(key, id)
+		if err != nil {
+			t.Fatalf("Obfuscate error: %v", err)
+		}
+		if o == id {
+			t.Fatalf("Obfuscate(%q) returned the input", id)
+		}
+		again, _ := Obfuscate(key, id)
+		if again != o {
+			t.Fatalf("Obfuscate not stable: %q vs %q", o, again)
+		}
+		back, err := Deobfuscate(key, o)
+		if err != nil {
+			t.Fatalf("Deobfuscate error: %v", err)
+		}
+		if back != id {
+			t.Fatalf("round trip: got %q want %q", back, id)
+		}
+	}
+}
+
+func TestObfuscate_ScattersConsecutiveV7(t *testing.T) {
+	key := []byte("secret")
+	b := newV7()
+	prev, _ := Obfuscate(key, canonicalString(b))
+	for i := 0; i < 50; i++ {
+		incrementV7(b)
+		o, err := Obfuscate(key, canonicalString(b))
+		if err != nil {
+			t.Fatalf("Obfuscate error: %v", err)
+		}
+		if o[:8] == prev[:8] {
+			t.Fatalf("consecutive IDs share an obfuscated prefix: %q, %q", prev, o)
+		}
+		x, _ := decodeUUIDCore(o)
+		y, _ := decodeUUIDCore(prev)
+		diff := 0
+		for j := range x {
+			diff += bits.OnesCount8(x[j] ^ y[j])
+		}
+		if diff < 32 {
+			t.Fatalf("consecutive IDs differ in only %d bits after obfuscation", diff)
+		}
+		prev = o
+	}
+}
+
+func TestObfuscate_KeyMatters(t *testing.T) {
+	id := UuidV7(true)
+	a, _ := Obfuscate([]byte("one"), id)
+	b, _ := Obfuscate([]byte("two"), id)
+	if a == b {
+		t.Fatalf("different keys produced the same output %q", a)
+	}
+	if back, _ := Deobfuscate([]byte("two"), a); back == id {
+		t.Fatal("wrong key reversed the obfuscation")
+	}
+}
+
+func TestObfuscate_Errors(t *testing.T) {
+	if _, err := Obfuscate(nil, UuidV4()); err == nil {
+		t.Fatal("expected error for empty key")
+	}
+	if _, err := Obfuscate([]byte("k"), "not-a-uuid"); err == nil || strings.Contains(err.Error(), "key") {
+		t.Fatalf("expected parse error, got %v", err)
+	}
+	if _, err := Deobfuscate([]byte{}, UuidV4()); err == nil {
+		t.Fatal("expected error for empty key")
+	}
+}
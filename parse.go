@@ -0,0 +1,182 @@
+package uid
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Textual UUID formats recognized by the parser.
+const (
+	// FormatBare is the 32-character form without hyphens.
+	FormatBare = "bare"
+	// FormatHyphenated is the 36-character 8-4-4-4-12 form.
+	FormatHyphenated = "hyphenated"
+	// FormatURN is the hyphenated form prefixed with "urn:uuid:".
+	FormatURN = "urn"
+	// FormatBraced is the hyphenated form wrapped in curly braces.
+	FormatBraced = "braced"
+)
+
+// uuidFormats lists the accepted textual forms in the order the parser
+// tries them. Each entry strips its decoration and returns the bare or
+// hyphenated core.
+var uuidFormats = []struct {
+	name  string
+	strip func(s string) (string, bool)
+}{
+	{FormatURN, func(s string) (string, bool) {
+		const prefix = "urn:uuid:"
+		if len(s) != len(prefix)+36 || !strings.EqualFold(s[:len(prefix)], prefix) {
+			return "", false
+		}
+		return s[len(prefix):], true
+	}},
+	{FormatBraced, func(s string) (string, bool) {
+		if len(s) != 38 || s[0] != '{' || s[37] != '}' {
+			return "", false
+		}
+		return s[1:37], true
+	}},
+	{FormatHyphenated, func(s string) (string, bool) {
+		return s, len(s) == 36
+	}},
+	{FormatBare, func(s string) (string, bool) {
+		return s, len(s) == 32
+	}},
+}
+
+// Parse decodes a UUID string into its 16 bytes. It is the inverse of the
+// UUID generators, for either output form.
+//
+// Example: Parse("550e8400-e29b-41d4-a716-446655440000") => []byte{0x55, 0x0e, 0x84, ...}
+//
+// Parameters:
+// - s: a 32-character bare or 36-character hyphenated UUID; a "urn:uuid:"
+// prefix or surrounding braces are tolerated, hex is case-insensitive
+//
+// Returns:
+// - The 16 decoded bytes
+// - An error describing a wrong length, a misplaced hyphen or a non-hex
+// character
+func Parse(s string) ([]byte, error) {
+	b, _, err := ParseWithFormat(s)
+	return b, err
+}
+
+// ParseWithFormat decodes a UUID string into its 16 bytes and reports the
+// textual form it was written in, so callers can echo it back in the same
+// style.
+//
+// Example: ParseWithFormat("{550e8400-e29b-41d4-a716-446655440000}") => bytes, "braced"
+//
+// Parameters:
+// - s: a bare, hyphenated, URN or braced UUID (hex is case-insensitive)
+//
+// Returns:
+// - The 16 decoded bytes
+// - One of FormatBare, FormatHyphenated, FormatURN or FormatBraced
+// - An error if s is not a valid UUID in any supported form
+func ParseWithFormat(s string) (b []byte, format string, err error) {
+	for _, f := range uuidFormats {
+		core, ok := f.strip(s)
+		if !ok {
+			continue
+		}
+		b, err = decodeUUIDCore(core)
+		if err != nil {
+			return nil, "", err
+		}
+		return b, f.name, nil
+	}
+	return nil, "", fmt.Errorf("invalid UUID length %d: %q", len(s), s)
+}
+
+// decodeUUIDCore decodes a 32-character bare or 36-character hyphenated UUID.
+func decodeUUIDCore(s string) ([]byte, error) {
+	var hexstr []byte
+	switch len(s) {
+	case 32:
+		hexstr = []byte(s)
+	case 36:
+		for _, i := range []int{8, 13, 18, 23} {
+			if s[i] != '-' {
+				return nil, fmt.Errorf("invalid UUID %q: expected hyphen at index %d", s, i)
+			}
+		}
+		hexstr = make([]byte, 0, 32)
+		hexstr = append(hexstr, s[0:8]...)
+		hexstr = append(hexstr, s[9:13]...)
+		hexstr = append(hexstr, s[14:18]...)
+		hexstr = append(hexstr, s[19:23]...)
+		hexstr = append(hexstr, s[24:36]...)
+	default:
+		return nil, errors.New("invalid UUID length")
+	}
+	b := make([]byte, 16)
+	if _, err := hex.Decode(b, hexstr); err != nil {
+		return nil, fmt.Errorf("invalid UUID %q: %w", s, err)
+	}
+	return b, nil
+}
+
+// ParseCanonical validates s and returns its canonical form together with
+// its version, in one pass.
+//
+// Example: ParseCanonical("{550E8400-E29B-41D4-A716-446655440000}") => "550e8400-e29b-41d4-a716-446655440000", 4
+//
+// Parameters:
+// - s: a UUID in any form accepted by ParseWithFormat
+//
+// Returns:
+// - The lowercase hyphenated UUID
+// - The version nibble (0-15)
+// - An error if s is invalid
+func ParseCanonical(s string) (canonical string, version int, err error) {
+	b, _, err := ParseWithFormat(s)
+	if err != nil {
+		return "", 0, err
+	}
+	return canonicalString(b), int(b[6] >> 4), nil
+}
+
+// Normalize returns the canonical form of s: lowercase, 36 characters,
+// hyphenated. Use it once at the boundary to store and compare UUIDs
+// received in mixed forms.
+//
+// Example: Normalize("URN:UUID:550E8400-E29B-41D4-A716-446655440000") => "550e8400-e29b-41d4-a716-446655440000"
+//
+// Parameters:
+// - s: a bare, hyphenated, URN or braced UUID in either case
+//
+// Returns:
+// - The canonical UUID, or an error if s is invalid
+func Normalize(s string) (string, error) {
+	b, _, err := ParseWithFormat(s)
+	if err != nil {
+		return "", err
+	}
+	return canonicalString(b), nil
+}
+
+// FirstValid returns the canonical form of the first candidate that parses
+// as a UUID, for fallback chains such as several request headers.
+//
+// Example: FirstValid("", "garbage", "550E8400E29B41D4A716446655440000") => "550e8400-e29b-41d4-a716-446655440000"
+//
+// Parameters:
+// - candidates: UUIDs in any form accepted by ParseWithFormat, in order of
+// preference
+//
+// Returns:
+// - The lowercase hyphenated form of the first valid candidate
+// - An error if no candidate is valid
+func FirstValid(candidates ...string) (string, error) {
+	for _, c := range candidates {
+		if b, _, err := ParseWithFormat(c); err == nil {
+			return canonicalString(b), nil
+		}
+	}
+	return "", fmt.Errorf("none of %d candidates is a valid UUID", len(candidates))
+}
@@ -0,0 +1,249 @@
+package uid
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestParseWithFormat(t *testing.T) {
+	want := []byte{0x55, 0x0e, 0x84, 0x00, 0xe2, 0x9b, 0x41, 0xd4, 0xa7, 0x16, 0x44, 0x66, 0x55, 0x44, 0x00, 0x00}
+	cases := []struct {
+		in     string
+		format string
+	}{
+		{"550e8400e29b41d4a716446655440000", FormatBare},
+		{"550E8400E29B41D4A716446655440000", FormatBare},
+		{"550e8400-e29b-41d4-a716-446655440000", FormatHyphenated},
+		{"urn:uuid:550e8400-e29b-41d4-a716-446655440000", FormatURN},
+		{"URN:UUID:550e8400-e29b-41d4-a716-446655440000", FormatURN},
+		{"{550e8400-e29b-41d4-a716-446655440000}", FormatBraced},
+	}
+	for _, c := range cases {
+		b, format, err := ParseWithFormat(c.in)
+		if err != nil {
+			t.Fatalf("ParseWithFormat(%q) error: %v", c.in, err)
+		}
+		if !bytes.Equal(b, want) {
+			t.Fatalf("ParseWithFormat(%q) bytes = %x, want %x", c.in, b, want)
+		}
+		if format != c.format {
+			t.Fatalf("ParseWithFormat(%q) format = %q, want %q", c.in, format, c.format)
+		}
+	}
+}
+
+func TestParseWithFormat_Invalid(t *testing.T) {
+	cases := []string{
+		"",
+		"550e8400e29b41d4a71644665544000",
+		"550e8400-e29b-41d4-a716-44665544000g",
+		"550e8400e-29b-41d4-a716-446655440000",
+		"urn:uuid:550e8400e29b41d4a716446655440000",
+		"{550e8400e29b41d4a716446655440000}",
+		"(550e8400-e29b-41d4-a716-446655440000)",
+	}
+	for _, in := range cases {
+		if _, _, err := ParseWithFormat(in); err == nil {
+			t.Fatalf("ParseWithFormat(%q) expected error", in)
+		}
+	}
+}
+
+func TestParseCanonical(t *testing.T) {
+	inputs := []string{
+		"550e8400e29b41d4a716446655440000",
+		"550E8400-E29B-41D4-A716-446655440000",
+		"urn:uuid:550e8400-e29b-41d4-a716-446655440000",
+		"{550e8400-e29b-41d4-a716-446655440000}",
+	}
+	for _, in := range inputs {
+		canonical, version, err := ParseCanonical(in)
+		if err != nil {
+			t.Fatalf("ParseCanonical(%q) error: %v", in, err)
+		}
+		if canonical != "550e8400-e29b-41d4-a716-446655440000" || version != 4 {
+			t.Fatalf("ParseCanonical(%q) = %q, %d", in, canonical, version)
+		}
+	}
+
+	ns := string(make([]byte, 16))
+	v3, _ := UuidV3(ns, []byte("name"))
+	v5, _ := UuidV5(ns, []byte("name"))
+	for want, id := range map[int]string{1: UuidV1(), 3: v3, 4: UuidV4(true), 5: v5, 6: UuidV6(), 7: UuidV7(true), 8: UuidV8Linked(nil)} {
+		_, version, err := ParseCanonical(id)
+		if err != nil {
+			t.Fatalf("ParseCanonical(%q) error: %v", id, err)
+		}
+		if version != want {
+			t.Fatalf("ParseCanonical(%q) version = %d, want %d", id, version, want)
+		}
+	}
+
+	if _, _, err := ParseCanonical("invalid"); err == nil {
+		t.Fatal("ParseCanonical expected error for invalid UUID")
+	}
+}
+
+func TestParseCanonical_IgnoresDefaultCase(t *testing.T) {
+	SetDefaultCase(true)
+	t.Cleanup(func() { SetDefaultCase(false) })
+
+	canonical, _, err := ParseCanonical(UuidV4())
+	if err != nil {
+		t.Fatalf("ParseCanonical error: %v", err)
+	}
+	if canonical != strings.ToLower(canonical) {
+		t.Fatalf("ParseCanonical must return lowercase: %s", canonical)
+	}
+}
+
+func TestFirstValid(t *testing.T) {
+	const want = "550e8400-e29b-41d4-a716-446655440000"
+	cases := [][]string{
+		{want},
+		{"", want},
+		{"garbage", "550E8400E29B41D4A716446655440000", "6ba7b810-9dad-11d1-80b4-00c04fd430c8"},
+		{"550e8400-e29b-41d4-a716-44665544000g", "{550e8400-e29b-41d4-a716-446655440000}"},
+	}
+	for _, c := range cases {
+		got, err := FirstValid(c...)
+		if err != nil {
+			t.Fatalf("FirstValid(%q) error: %v", c, err)
+		}
+		if got != want {
+			t.Fatalf("FirstValid(%q) = %q, want %q", c, got, want)
+		}
+	}
+}
+
+func TestFirstValid_NoneValid(t *testing.T) {
+	for _, c := range [][]string{nil, {""}, {"garbage", "550e8400"}} {
+		if _, err := FirstValid(c...); err == nil {
+			t.Fatalf("FirstValid(%q) expected error", c)
+		}
+	}
+}
+
+func TestParse_RoundTrip(t *testing.T) {
+	for _, formatted := range []bool{false, true} {
+		for _, s := range []string{UuidV1(formatted), UuidV4(formatted), UuidV6(formatted), UuidV7(formatted)} {
+			b, err := Parse(s)
+			if err != nil {
+				t.Fatalf("Parse(%q) error: %v", s, err)
+			}
+			if len(b) != 16 {
+				t.Fatalf("Parse(%q) returned %d bytes", s, len(b))
+			}
+			if got := bytesToUUIDString(b, formatted); got != s {
+				t.Fatalf("round trip: got %q want %q", got, s)
+			}
+		}
+	}
+	for _, s := range []string{
+		"urn:uuid:550e8400-e29b-41d4-a716-446655440000",
+		"{550e8400-e29b-41d4-a716-446655440000}",
+	} {
+		b, err := Parse(s)
+		if err != nil {
+			t.Fatalf("Parse(%q) error: %v", s, err)
+		}
+		if got := canonicalString(b); got != "550e8400-e29b-41d4-a716-446655440000" {
+			t.Fatalf("Parse(%q) = %s", s, got)
+		}
+	}
+}
+
+func TestParse_Errors(t *testing.T) {
+	cases := map[string]string{
+		"550e8400e29b41d4a71644665544000":      "length",
+		"550e8400e-29b-41d4-a716-446655440000": "hyphen",
+		"550e8400-e29b-41d4-a716-44665544000g": "invalid byte",
+	}
+	for in, want := range cases {
+		_, err := Parse(in)
+		if err == nil {
+			t.Fatalf("Parse(%q) expected error", in)
+		}
+		if !strings.Contains(err.Error(), want) {
+			t.Fatalf("Parse(%q) error %q does not mention %q", in, err, want)
+		}
+	}
+}
+
+func TestNormalize(t *testing.T) {
+	const want = "550e8400-e29b-41d4-a716-446655440000"
+	inputs := []string{
+		want,
+		"550E8400-E29B-41D4-A716-446655440000",
+		"550e8400e29b41d4a716446655440000",
+		"550E8400E29B41D4A716446655440000",
+		"urn:uuid:550e8400-e29b-41d4-a716-446655440000",
+		"URN:UUID:550E8400-E29B-41D4-A716-446655440000",
+		"{550e8400-e29b-41d4-a716-446655440000}",
+		"{550E8400-E29B-41D4-A716-446655440000}",
+	}
+	for _, in := range inputs {
+		got, err := Normalize(in)
+		if err != nil {
+			t.Fatalf("Normalize(%q) error: %v", in, err)
+		}
+		if got != want {
+			t.Fatalf("Normalize(%q) = %q, want %q", in, got, want)
+		}
+	}
+
+	for _, in := range []string{"", "550e8400", "{550e8400e29b41d4a716446655440000}", " 550e8400-e29b-41d4-a716-446655440000"} {
+		if _, err := Normalize(in); err == nil {
+			t.Fatalf("Normalize(%q) expected error", in)
+		}
+	}
+}
+
+func FuzzParse(f *testing.F) {
+	for _, seed := range []string{
+		"550e8400-e29b-41d4-a716-446655440000",
+		"550E8400E29B41D4A716446655440000",
+		"urn:uuid:550e8400-e29b-41d4-a716-446655440000",
+		"{550e8400-e29b-41d4-a716-446655440000}",
+		"00000000-0000-0000-0000-000000000000",
+		"ffffffff-ffff-ffff-ffff-ffffffffffff",
+		"",
+		"-",
+		"550e8400-e29b-41d4-a716-44665544000",
+		"550e8400e-29b-41d4-a716-446655440000",
+		"550e8400-e29b-41d4-a716-44665544000g",
+		"{550e8400e29b41d4a716446655440000}",
+		"urn:uuid:550e8400e29b41d4a716446655440000",
+		"------------------------------------",
+	} {
+		f.Add(seed)
+	}
+	f.Fuzz(func(t *testing.T, s string) {
+		b, err := Parse(s)
+		validErr := Validate(s)
+		if err != nil {
+			if validErr == nil {
+				t.Fatalf("Validate accepts %q but Parse fails: %v", s, err)
+			}
+			if _, nerr := Normalize(s); nerr == nil {
+				t.Fatalf("Normalize accepts %q but Parse fails: %v", s, err)
+			}
+			return
+		}
+		if len(b) != 16 {
+			t.Fatalf("Parse(%q) returned %d bytes", s, len(b))
+		}
+		canonical, err := Normalize(s)
+		if err != nil {
+			t.Fatalf("Normalize(%q) error after a successful Parse: %v", s, err)
+		}
+		if got := canonicalString(b); got != canonical {
+			t.Fatalf("Parse(%q) canonical form %q, Normalize %q", s, got, canonical)
+		}
+		again, err := Parse(canonical)
+		if err != nil || !bytes.Equal(again, b) {
+			t.Fatalf("Parse(%q) = %x, %v; want %x", canonical, again, err, b)
+		}
+	})
+}
@@ -0,0 +1,181 @@
+package uid
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// NamespacedID returns a reverse-DNS style identifier: prefix followed by a
+// dot and a version 7 UUID encoded as 26 lowercase Crockford Base32
+// characters.
+//
+// Example: com.example.plugin.01m53vtawqf3ebd09ajff4f0zd
+// (length: len(prefix) + 27, the dot plus 26 characters)
+//
+// Parameters:
+// - prefix: a reverse-DNS name such as "com.example.plugin"
+//
+// Returns:
+// - The namespaced ID, or an error if prefix is not a valid reverse-DNS name
+func NamespacedID(prefix string) (string, error) {
+	if err := validateReverseDNS(prefix); err != nil {
+		return "", err
+	}
+	return prefix + "." + strings.ToLower(encodeCrockford(newV7())), nil
+}
+
+// SplitNamespacedID splits an identifier produced by NamespacedID into its
+// reverse-DNS prefix and its Base32 ID.
+//
+// Example: com.example.plugin.01m53vtawqf3ebd09ajff4f0zd => "com.example.plugin", "01m53vtawqf3ebd09ajff4f0zd"
+//
+// Parameters:
+// - s: the namespaced identifier
+//
+// Returns:
+// - The prefix and the ID, or an error if either part is invalid
+func SplitNamespacedID(s string) (prefix, id string, err error) {
+	i := strings.LastIndexByte(s, '.')
+	if i < 0 {
+		return "", "", errors.New("namespaced ID has no prefix")
+	}
+	prefix, id = s[:i], s[i+1:]
+	if err := validateReverseDNS(prefix); err != nil {
+		return "", "", err
+	}
+	if _, err := decodeCrockford(id); err != nil {
+		return "", "", fmt.Errorf("invalid namespaced ID %q: %w", id, err)
+	}
+	return prefix, id, nil
+}
+
+// validateReverseDNS checks that s is made of at least two dot-separated
+// labels of lowercase letters, digits and inner hyphens, as in DNS.
+func validateReverseDNS(s string) error {
+	if len(s) > 253 {
+		return errors.New("reverse-DNS prefix longer than 253 characters")
+	}
+	labels := strings.Split(s, ".")
+	if len(labels) < 2 {
+		return fmt.Errorf("reverse-DNS prefix %q needs at least two labels", s)
+	}
+	for _, label := range labels {
+		if label == "" || len(label) > 63 {
+			return fmt.Errorf("reverse-DNS prefix %q has an empty or too long label", s)
+		}
+		if label[0] == '-' || label[len(label)-1] == '-' {
+			return fmt.Errorf("reverse-DNS label %q must not start or end with a hyphen", label)
+		}
+		for i := 0; i < len(label); i++ {
+			c := label[i]
+			if !(c >= 'a' && c <= 'z' || c >= '0' && c <= '9' || c == '-') {
+				return fmt.Errorf("reverse-DNS label %q contains invalid character %q", label, c)
+			}
+		}
+	}
+	return nil
+}
+
+// ParsePrefixed splits an application-prefixed ID such as "acct_<uuid>"
+// into its prefix and its UUID. The split happens at the last occurrence of
+// sep that leaves a valid UUID after it, so separators that also occur
+// inside a hyphenated UUID (such as "-") work too. Input without a
+// separator is parsed as a bare UUID with an empty prefix.
+//
+// Example: ParsePrefixed("acct_550E8400E29B41D4A716446655440000", "_") => "acct", "550e8400-e29b-41d4-a716-446655440000"
+//
+// Parameters:
+// - s: the prefixed or unprefixed ID
+// - sep: the separator between prefix and UUID, must not be empty
+//
+// Returns:
+// - The prefix (empty if there is none)
+// - The UUID in canonical form
+// - An error if no valid UUID follows the prefix
+func ParsePrefixed(s string, sep string) (prefix string, uuid string, err error) {
+	if sep == "" {
+		return "", "", errors.New("separator must not be empty")
+	}
+	for end := len(s); ; {
+		i := strings.LastIndex(s[:end], sep)
+		if i < 0 {
+			break
+		}
+		if b, _, err := ParseWithFormat(s[i+len(sep):]); err == nil {
+			return s[:i], canonicalString(b), nil
+		}
+		end = i
+	}
+	b, _, err := ParseWithFormat(s)
+	if err != nil {
+		return "", "", fmt.Errorf("no valid UUID in prefixed ID %q: %w", s, err)
+	}
+	return "", canonicalString(b), nil
+}
+
+// prefixSep separates the prefix from the ID in WithPrefix.
+const prefixSep = "_"
+
+// WithPrefix returns id prefixed Stripe-style with prefix and an
+// underscore, making IDs self-describing in logs. Reverse it with
+// StripPrefix, or with ParsePrefixed(s, "_") for UUIDs.
+//
+// Example: WithPrefix("user", "01H455VB4PEX5VSKNK084SN02Q") => "user_01H455VB4PEX5VSKNK084SN02Q"
+//
+// Parameters:
+// - prefix: the type prefix, such as "user" or "acct"
+// - id: the ID to prefix
+//
+// Returns:
+// - The prefixed ID
+func WithPrefix(prefix string, id string) string {
+	return prefix + prefixSep + id
+}
+
+// PrefixedUlid returns a new ULID prefixed with prefix and an underscore.
+//
+// Example: PrefixedUlid("user") => "user_01H455VB4PEX5VSKNK084SN02Q"
+//
+// Parameters:
+// - prefix: the type prefix
+//
+// Returns:
+// - The prefixed ULID
+func PrefixedUlid(prefix string) string {
+	return WithPrefix(prefix, Ulid())
+}
+
+// PrefixedUuidV7 returns a new version 7 UUID (without hyphens) prefixed
+// with prefix and an underscore.
+//
+// Example: PrefixedUuidV7("acct") => "acct_01890a5dac96774bbcceb302099a8057"
+//
+// Parameters:
+// - prefix: the type prefix
+//
+// Returns:
+// - The prefixed UUID
+func PrefixedUuidV7(prefix string) string {
+	return WithPrefix(prefix, UuidV7())
+}
+
+// StripPrefix removes a known prefix and its underscore from an ID built by
+// WithPrefix, so the rest can be passed to Parse, Validate or ParseUlid.
+//
+// Example: StripPrefix("user_01H455VB4PEX5VSKNK084SN02Q", "user") => "01H455VB4PEX5VSKNK084SN02Q"
+//
+// Parameters:
+// - s: the prefixed ID
+// - prefix: the expected prefix
+//
+// Returns:
+// - The ID without prefix, or an error if s does not start with prefix
+// and an underscore
+func StripPrefix(s string, prefix string) (string, error) {
+	id, ok := strings.CutPrefix(s, prefix+prefixSep)
+	if !ok {
+		return "", fmt.Errorf("ID %q does not have the prefix %q", s, prefix+prefixSep)
+	}
+	return id, nil
+}
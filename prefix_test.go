@@ -0,0 +1,156 @@
+package uid
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestNamespacedID(t *testing.T) {
+	id, err := NamespacedID("com.example.plugin")
+	if err != nil {
+		t.Fatalf("NamespacedID error: %v", err)
+	}
+	if !strings.HasPrefix(id, "com.example.plugin.") {
+		t.Fatalf("NamespacedID = %s, missing prefix", id)
+	}
+	if len(id) != len("com.example.plugin.")+26 {
+		t.Fatalf("NamespacedID length = %d; value=%s", len(id), id)
+	}
+	if id != strings.ToLower(id) {
+		t.Fatalf("NamespacedID must be lowercase: %s", id)
+	}
+
+	prefix, short, err := SplitNamespacedID(id)
+	if err != nil {
+		t.Fatalf("SplitNamespacedID error: %v", err)
+	}
+	if prefix != "com.example.plugin" || prefix+"."+short != id {
+		t.Fatalf("SplitNamespacedID(%s) = %q, %q", id, prefix, short)
+	}
+
+	// the documented example
+	prefix, short, err = SplitNamespacedID("com.example.plugin.01m53vtawqf3ebd09ajff4f0zd")
+	if err != nil || prefix != "com.example.plugin" || short != "01m53vtawqf3ebd09ajff4f0zd" {
+		t.Fatalf("SplitNamespacedID(example) = %q, %q, %v", prefix, short, err)
+	}
+}
+
+func TestNamespacedID_InvalidPrefix(t *testing.T) {
+	cases := []string{
+		"",
+		"com",
+		"com..example",
+		".com.example",
+		"com.example.",
+		"com.-example",
+		"com.example-",
+		"Com.Example",
+		"com.exa_mple",
+		"com." + strings.Repeat("a", 64),
+	}
+	for _, prefix := range cases {
+		if _, err := NamespacedID(prefix); err == nil {
+			t.Fatalf("NamespacedID(%q) expected error", prefix)
+		}
+	}
+}
+
+func TestSplitNamespacedID_Invalid(t *testing.T) {
+	cases := []string{
+		"01m53vtawqf3ebd09ajff4f0zd",
+		"com.01m53vtawqf3ebd09ajff4f0zd",
+		"com.example.not-an-id",
+		"com.example.01m53vtawqf3ebd09ajff4f0z!",
+	}
+	for _, s := range cases {
+		if _, _, err := SplitNamespacedID(s); err == nil {
+			t.Fatalf("SplitNamespacedID(%q) expected error", s)
+		}
+	}
+}
+
+func TestParsePrefixed(t *testing.T) {
+	const want = "550e8400-e29b-41d4-a716-446655440000"
+	cases := []struct {
+		in, sep, prefix string
+	}{
+		{"acct_550e8400-e29b-41d4-a716-446655440000", "_", "acct"},
+		{"acct_550E8400E29B41D4A716446655440000", "_", "acct"},
+		{"org_acct_550e8400e29b41d4a716446655440000", "_", "org_acct"},
+		{"acct-550e8400-e29b-41d4-a716-446655440000", "-", "acct"},
+		{"acct::{550e8400-e29b-41d4-a716-446655440000}", "::", "acct"},
+		{"550e8400-e29b-41d4-a716-446655440000", "_", ""},
+		{"550e8400-e29b-41d4-a716-446655440000", "-", ""},
+	}
+	for _, c := range cases {
+		prefix, uuid, err := ParsePrefixed(c.in, c.sep)
+		if err != nil {
+			t.Fatalf("ParsePrefixed(%q, %q) error: %v", c.in, c.sep, err)
+		}
+		if prefix != c.prefix || uuid != want {
+			t.Fatalf("ParsePrefixed(%q, %q) = %q, %q; want %q, %q", c.in, c.sep, prefix, uuid, c.prefix, want)
+		}
+	}
+}
+
+func TestParsePrefixed_Invalid(t *testing.T) {
+	cases := []struct{ in, sep string }{
+		{"acct_550e8400-e29b-41d4-a716-44665544000z", "_"},
+		{"acct_", "_"},
+		{"acct", "_"},
+		{"acct_550e8400-e29b-41d4-a716-446655440000", ""},
+	}
+	for _, c := range cases {
+		if _, _, err := ParsePrefixed(c.in, c.sep); err == nil {
+			t.Fatalf("ParsePrefixed(%q, %q) expected error", c.in, c.sep)
+		}
+	}
+}
+
+func TestWithPrefix(t *testing.T) {
+	if got := WithPrefix("user", "01H455VB4PEX5VSKNK084SN02Q"); got != "user_01H455VB4PEX5VSKNK084SN02Q" {
+		t.Fatalf("WithPrefix = %q", got)
+	}
+
+	id := PrefixedUlid("user")
+	if !strings.HasPrefix(id, "user_") || len(id) != len("user_")+26 {
+		t.Fatalf("PrefixedUlid = %q", id)
+	}
+	ulid, err := StripPrefix(id, "user")
+	if err != nil {
+		t.Fatalf("StripPrefix(%q) error: %v", id, err)
+	}
+	if _, err := ParseUlid(ulid); err != nil {
+		t.Fatalf("ParseUlid(%q) error: %v", ulid, err)
+	}
+	if WithPrefix("user", ulid) != id {
+		t.Fatalf("round trip: %q", id)
+	}
+
+	// prefixes may contain the separator themselves
+	v7 := PrefixedUuidV7("billing_acct")
+	bare, err := StripPrefix(v7, "billing_acct")
+	if err != nil || !IsValid(bare) {
+		t.Fatalf("StripPrefix(%q) = %q, %v", v7, bare, err)
+	}
+	prefix, canonical, err := ParsePrefixed(v7, "_")
+	if err != nil || prefix != "billing_acct" {
+		t.Fatalf("ParsePrefixed(%q) = %q, %q, %v", v7, prefix, canonical, err)
+	}
+	if got, _ := Normalize(bare); got != canonical {
+		t.Fatalf("ParsePrefixed UUID = %q, want %q", canonical, got)
+	}
+}
+
+func TestStripPrefix_Invalid(t *testing.T) {
+	for _, c := range [][2]string{
+		{"user_01H455VB4PEX5VSKNK084SN02Q", "acct"},
+		{"user01H455VB4PEX5VSKNK084SN02Q", "user"},
+		{"", "user"},
+		{"users_01H455VB4PEX5VSKNK084SN02Q", "user"},
+	} {
+		if _, err := StripPrefix(c[0], c[1]); err == nil {
+			t.Fatalf("StripPrefix(%q, %q) expected error", c[0], c[1])
+		}
+	}
+}
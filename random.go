@@ -0,0 +1,21 @@
+package uid
+
+import (
+	"crypto/rand"
+	"io"
+)
+
+// Reader is the source of randomness of every generator in the package,
+// and of Generator values whose own Reader is nil. It defaults to
+// crypto/rand.Reader.
+//
+// Replace it only in tests, e.g. with a fixed-content reader to get exact
+// UUIDs, and before any concurrent generation starts: it is read without
+// synchronization.
+var Reader io.Reader = rand.Reader
+
+// readRandom fills b from Reader.
+func readRandom(b []byte) error {
+	_, err := io.ReadFull(Reader, b)
+	return err
+}
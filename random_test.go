@@ -0,0 +1,85 @@
+package uid
+
+import (
+	"bytes"
+	"testing"
+	"time"
+)
+
+// useFixedReader makes Reader yield the given bytes, repeated, for the
+// duration of the test.
+func useFixedReader(t *testing.T, b []byte) {
+	t.Helper()
+	prev := Reader
+	Reader = bytes.NewReader(bytes.Repeat(b, 64))
+	t.Cleanup(func() { Reader = prev })
+}
+
+var sequentialBytes = []byte{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15}
+
+func TestReader_DeterministicV4(t *testing.T) {
+	useFixedReader(t, sequentialBytes)
+	if got, want := UuidV4(true), "00010203-0405-4607-8809-0a0b0c0d0e0f"; got != want {
+		t.Fatalf("UuidV4 = %q, want %q", got, want)
+	}
+}
+
+func TestReader_DeterministicV7(t *testing.T) {
+	useFixedReader(t, sequentialBytes)
+	// a past clock would otherwise continue from the last v7 handed out
+	mu.Lock()
+	saved := lastV7
+	lastV7 = [16]byte{}
+	mu.Unlock()
+	defer func() {
+		mu.Lock()
+		lastV7 = saved
+		mu.Unlock()
+	}()
+	nowFunc = func() time.Time { return time.UnixMilli(0x01890a5dac96) }
+	defer func() { nowFunc = time.Now }()
+	if got, want := UuidV7(true), "01890a5d-ac96-7001-8203-040506070809"; got != want {
+		t.Fatalf("UuidV7 = %q, want %q", got, want)
+	}
+}
+
+func TestReader_GeneratorFallback(t *testing.T) {
+	useFixedReader(t, sequentialBytes)
+	var g Generator
+	got, err := g.V4(true)
+	if err != nil {
+		t.Fatalf("V4 error: %v", err)
+	}
+	if want := "00010203-0405-4607-8809-0a0b0c0d0e0f"; got != want {
+		t.Fatalf("Generator.V4 = %q, want %q", got, want)
+	}
+}
+
+func TestUuidV4Err(t *testing.T) {
+	s, err := UuidV4Err(true)
+	if err != nil {
+		t.Fatalf("UuidV4Err error: %v", err)
+	}
+	assertLenAndVersion(t, s, 36, '4', true)
+
+	useFixedReader(t, sequentialBytes)
+	if s, _ := UuidV4Err(); s != "000102030405460788090a0b0c0d0e0f" {
+		t.Fatalf("UuidV4Err = %q", s)
+	}
+}
+
+func TestUuidV4Err_FailingReader(t *testing.T) {
+	prev := Reader
+	Reader = failingReader{}
+	defer func() { Reader = prev }()
+
+	s, err := UuidV4Err()
+	if err == nil {
+		t.Fatalf("UuidV4Err = %q, expected error", s)
+	}
+	if s != "" {
+		t.Fatalf("UuidV4Err returned %q alongside an error", s)
+	}
+	// UuidV4 keeps degrading silently for backward compatibility
+	assertLenAndVersion(t, UuidV4(), 32, '4', false)
+}
@@ -0,0 +1,61 @@
+package uid
+
+import "fmt"
+
+// UuidV1Bytes returns a version 1 UUID as its 16 raw bytes, without the
+// string encoding of UuidV1, for fixed-size binary columns.
+func UuidV1Bytes() [16]byte {
+	return [16]byte(newV1())
+}
+
+// UuidV4Bytes returns a random (version 4) UUID as its 16 raw bytes,
+// without the string encoding of UuidV4, for fixed-size binary columns.
+func UuidV4Bytes() [16]byte {
+	return [16]byte(newV4())
+}
+
+// UuidV6Bytes returns a version 6 UUID as its 16 raw bytes, without the
+// string encoding of UuidV6, for fixed-size binary columns.
+func UuidV6Bytes() [16]byte {
+	return [16]byte(newV6())
+}
+
+// UuidV7Bytes returns a version 7 UUID as its 16 raw bytes, without the
+// string encoding of UuidV7, for fixed-size binary columns. It shares the
+// monotonic sequence of UuidV7.
+func UuidV7Bytes() [16]byte {
+	return [16]byte(newV7())
+}
+
+// FromBytes returns the hyphenated string form of a 16-byte UUID received
+// in binary, the inverse of Parse. The hex case follows SetDefaultCase.
+//
+// Example: FromBytes([]byte{0x55, 0x0e, 0x84, ...}) => 550e8400-e29b-41d4-a716-446655440000
+//
+// Parameters:
+// - b: exactly 16 bytes
+//
+// Returns:
+// - The UUID with hyphens, or an error if b is not 16 bytes
+func FromBytes(b []byte) (string, error) {
+	if len(b) != 16 {
+		return "", fmt.Errorf("UUID must be 16 bytes, got %d", len(b))
+	}
+	return bytesToUUIDString(b, true), nil
+}
+
+// FromBytesUnformatted is FromBytes without hyphens.
+//
+// Example: FromBytesUnformatted([]byte{0x55, 0x0e, 0x84, ...}) => 550e8400e29b41d4a716446655440000
+//
+// Parameters:
+// - b: exactly 16 bytes
+//
+// Returns:
+// - The UUID without hyphens, or an error if b is not 16 bytes
+func FromBytesUnformatted(b []byte) (string, error) {
+	if len(b) != 16 {
+		return "", fmt.Errorf("UUID must be 16 bytes, got %d", len(b))
+	}
+	return bytesToUUIDString(b, false), nil
+}
@@ -0,0 +1,76 @@
+package uid
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestUuidBytes(t *testing.T) {
+	for version, fn := range map[int]func() [16]byte{
+		1: UuidV1Bytes,
+		4: UuidV4Bytes,
+		6: UuidV6Bytes,
+		7: UuidV7Bytes,
+	} {
+		a, b := fn(), fn()
+		if a == b {
+			t.Fatalf("v%d: two calls returned %x", version, a)
+		}
+		if got := UUID(a).Version(); got != version {
+			t.Fatalf("v%d: version nibble = %d", version, got)
+		}
+		if got := UUID(a).Variant(); got != "RFC4122" {
+			t.Fatalf("v%d: variant = %s", version, got)
+		}
+	}
+}
+
+func TestUuidV7Bytes_Monotonic(t *testing.T) {
+	prev := UuidV7Bytes()
+	for i := 0; i < 1000; i++ {
+		next := UuidV7Bytes()
+		if Compare(UUID(next).String(), UUID(prev).String()) <= 0 {
+			t.Fatalf("UuidV7Bytes not increasing: %x after %x", next, prev)
+		}
+		prev = next
+	}
+}
+
+func BenchmarkUuidV4(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_ = Uuid()
+	}
+}
+
+func BenchmarkUuidV4Bytes(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_ = UuidV4Bytes()
+	}
+}
+
+func TestFromBytes(t *testing.T) {
+	b := []byte{0x55, 0x0e, 0x84, 0x00, 0xe2, 0x9b, 0x41, 0xd4, 0xa7, 0x16, 0x44, 0x66, 0x55, 0x44, 0x00, 0x00}
+	if got, err := FromBytes(b); err != nil || got != "550e8400-e29b-41d4-a716-446655440000" {
+		t.Fatalf("FromBytes = %q, %v", got, err)
+	}
+	if got, err := FromBytesUnformatted(b); err != nil || got != "550e8400e29b41d4a716446655440000" {
+		t.Fatalf("FromBytesUnformatted = %q, %v", got, err)
+	}
+
+	id := UuidV7(true)
+	parsed, _ := Parse(id)
+	if got, _ := FromBytes(parsed); got != id {
+		t.Fatalf("round trip: got %q want %q", got, id)
+	}
+
+	for _, n := range []int{0, 15, 17, 32} {
+		if _, err := FromBytes(make([]byte, n)); err == nil || !strings.Contains(err.Error(), "16 bytes") {
+			t.Fatalf("FromBytes with %d bytes: error = %v", n, err)
+		}
+		if _, err := FromBytesUnformatted(make([]byte, n)); err == nil {
+			t.Fatalf("FromBytesUnformatted with %d bytes expected error", n)
+		}
+	}
+}
@@ -0,0 +1,44 @@
+package uid
+
+import (
+	"fmt"
+	"io"
+)
+
+// uuidReader is the io.Reader returned by NewUuidReader.
+type uuidReader struct {
+	generate func() []byte
+	version  int
+	pending  []byte // rest of the current line
+}
+
+// NewUuidReader returns an io.Reader yielding an endless stream of freshly
+// generated, newline-terminated hyphenated UUIDs, for load-testing tools.
+// A UUID split by a short buffer continues on the next Read.
+//
+// Example: io.Copy(os.Stdout, uid.NewUuidReader(4))
+//
+// Parameters:
+// - version: 1, 4, 6 or 7, the versions that need no caller input
+//
+// Returns:
+// - The reader; for any other version its Read returns an error
+func NewUuidReader(version int) io.Reader {
+	return &uuidReader{generate: generatorFor(version), version: version}
+}
+
+func (r *uuidReader) Read(p []byte) (int, error) {
+	if r.generate == nil {
+		return 0, fmt.Errorf("no standalone generator for UUID version %d", r.version)
+	}
+	n := 0
+	for n < len(p) {
+		if len(r.pending) == 0 {
+			r.pending = append([]byte(bytesToUUIDString(r.generate(), true)), '\n')
+		}
+		c := copy(p[n:], r.pending)
+		r.pending = r.pending[c:]
+		n += c
+	}
+	return n, nil
+}
@@ -0,0 +1,67 @@
+package uid
+
+import (
+	"bufio"
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestNewUuidReader(t *testing.T) {
+	for _, version := range []int{1, 4, 6, 7} {
+		// 10 lines of 37 bytes, read through an odd-sized buffer so UUIDs
+		// straddle Read calls
+		var sb strings.Builder
+		r := NewUuidReader(version)
+		buf := make([]byte, 7)
+		for sb.Len() < 370 {
+			n, err := r.Read(buf[:min(len(buf), 370-sb.Len())])
+			if err != nil {
+				t.Fatalf("Read error: %v", err)
+			}
+			sb.Write(buf[:n])
+		}
+
+		lines := strings.Split(strings.TrimSuffix(sb.String(), "\n"), "\n")
+		if len(lines) != 10 {
+			t.Fatalf("version %d: got %d lines, want 10", version, len(lines))
+		}
+		seen := map[string]bool{}
+		for _, line := range lines {
+			assertVersion(t, line, version)
+			if seen[line] {
+				t.Fatalf("duplicate UUID %q", line)
+			}
+			seen[line] = true
+		}
+	}
+}
+
+func TestNewUuidReader_LimitReader(t *testing.T) {
+	sc := bufio.NewScanner(io.LimitReader(NewUuidReader(4), 37*100))
+	count := 0
+	for sc.Scan() {
+		assertVersion(t, sc.Text(), 4)
+		count++
+	}
+	if count != 100 {
+		t.Fatalf("got %d UUIDs, want 100", count)
+	}
+}
+
+func TestNewUuidReader_Unsupported(t *testing.T) {
+	for _, version := range []int{0, 3, 5, 8, 9} {
+		if n, err := NewUuidReader(version).Read(make([]byte, 64)); err == nil || n != 0 {
+			t.Fatalf("version %d: Read = %d, %v; want an error", version, n, err)
+		}
+	}
+}
+
+// assertVersion fails unless s is a valid hyphenated UUID of the version.
+func assertVersion(t *testing.T, s string, version int) {
+	t.Helper()
+	_, got, err := ParseCanonical(s)
+	if err != nil || len(s) != 36 || got != version {
+		t.Fatalf("%q: version %d, err %v; want a hyphenated v%d", s, got, err, version)
+	}
+}
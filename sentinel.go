@@ -0,0 +1,30 @@
+package uid
+
+// NilUUID returns the nil UUID, all 128 bits zero (RFC 9562, section 5.9).
+// It is commonly used as a "no value" marker.
+//
+// Example: 00000000-0000-0000-0000-000000000000 (length: 36)
+func NilUUID() string {
+	return "00000000-0000-0000-0000-000000000000"
+}
+
+// MaxUUID returns the max UUID, all 128 bits one (RFC 9562, section 5.10).
+// It sorts after every other UUID, so ordered schemes use it as an
+// "infinity" marker.
+//
+// Example: ffffffff-ffff-ffff-ffff-ffffffffffff (length: 36)
+func MaxUUID() string {
+	return "ffffffff-ffff-ffff-ffff-ffffffffffff"
+}
+
+// IsNil reports whether s is the nil UUID in any form accepted by Parse.
+//
+// Parameters:
+// - s: the candidate UUID
+//
+// Returns:
+// - true if s parses and all its bytes are zero; false otherwise
+func IsNil(s string) bool {
+	b, err := Parse(s)
+	return err == nil && UUID(b).IsZero()
+}
@@ -0,0 +1,41 @@
+package uid
+
+import "testing"
+
+func TestNilUUID(t *testing.T) {
+	if got, want := NilUUID(), "00000000-0000-0000-0000-000000000000"; got != want {
+		t.Fatalf("NilUUID() = %q, want %q", got, want)
+	}
+	if !IsNil(NilUUID()) {
+		t.Fatal("IsNil(NilUUID()) = false")
+	}
+	if !IsNil("00000000000000000000000000000000") || !IsNil("{00000000-0000-0000-0000-000000000000}") {
+		t.Fatal("IsNil must accept every parsed form")
+	}
+}
+
+func TestMaxUUID(t *testing.T) {
+	if got, want := MaxUUID(), "ffffffff-ffff-ffff-ffff-ffffffffffff"; got != want {
+		t.Fatalf("MaxUUID() = %q, want %q", got, want)
+	}
+	b, err := Parse(MaxUUID())
+	if err != nil {
+		t.Fatalf("Parse(MaxUUID()) error: %v", err)
+	}
+	for _, c := range b {
+		if c != 0xFF {
+			t.Fatalf("MaxUUID bytes = %x", b)
+		}
+	}
+	if IsNil(MaxUUID()) {
+		t.Fatal("IsNil(MaxUUID()) = true")
+	}
+}
+
+func TestIsNil_Invalid(t *testing.T) {
+	for _, s := range []string{"", "0", "00000000-0000-0000-0000-00000000000", UuidV4(true)} {
+		if IsNil(s) {
+			t.Fatalf("IsNil(%q) = true", s)
+		}
+	}
+}
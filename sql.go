@@ -0,0 +1,60 @@
+package uid
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"sync/atomic"
+)
+
+// sqlBinary holds the storage format of UUID.Value set by SetSQLBinary.
+var sqlBinary atomic.Bool
+
+// SetSQLBinary selects how UUID values are written through database/sql:
+// as the canonical 36-character string (the default) or as the raw 16
+// bytes, for BINARY(16) and native UUID columns. Scan accepts both
+// regardless. It is meant to be called once at startup.
+//
+// Parameters:
+// - binary: true to write 16 raw bytes, false to write strings
+func SetSQLBinary(binary bool) {
+	sqlBinary.Store(binary)
+}
+
+// Value implements driver.Valuer. It returns the canonical lowercase
+// hyphenated string, or the raw 16 bytes after SetSQLBinary(true).
+func (u UUID) Value() (driver.Value, error) {
+	if sqlBinary.Load() {
+		return u[:], nil
+	}
+	return canonicalString(u[:]), nil
+}
+
+// Scan implements sql.Scanner. It accepts a UUID string in any form
+// accepted by Parse, the same as []byte text, 16 raw bytes, or nil, which
+// yields the nil UUID.
+func (u *UUID) Scan(src any) error {
+	switch v := src.(type) {
+	case nil:
+		*u = UUID{}
+		return nil
+	case string:
+		return u.scanString(v)
+	case []byte:
+		if len(v) == 16 {
+			copy(u[:], v)
+			return nil
+		}
+		return u.scanString(string(v))
+	}
+	return fmt.Errorf("uid: cannot scan %T into UUID", src)
+}
+
+// scanString parses s into u.
+func (u *UUID) scanString(s string) error {
+	parsed, err := ParseUUID(s)
+	if err != nil {
+		return fmt.Errorf("uid: cannot scan %q into UUID: %w", s, err)
+	}
+	*u = parsed
+	return nil
+}
@@ -0,0 +1,85 @@
+package uid
+
+import (
+	"bytes"
+	"database/sql"
+	"database/sql/driver"
+	"testing"
+)
+
+var (
+	_ sql.Scanner   = (*UUID)(nil)
+	_ driver.Valuer = UUID{}
+)
+
+func TestUUID_Value(t *testing.T) {
+	u, _ := ParseUUID("550E8400-E29B-41D4-A716-446655440000")
+	v, err := u.Value()
+	if err != nil {
+		t.Fatalf("Value error: %v", err)
+	}
+	if v != "550e8400-e29b-41d4-a716-446655440000" {
+		t.Fatalf("Value = %#v", v)
+	}
+
+	SetSQLBinary(true)
+	defer SetSQLBinary(false)
+	v, err = u.Value()
+	if err != nil {
+		t.Fatalf("Value error: %v", err)
+	}
+	if b, ok := v.([]byte); !ok || !bytes.Equal(b, u[:]) {
+		t.Fatalf("binary Value = %#v", v)
+	}
+}
+
+func TestUUID_Scan(t *testing.T) {
+	want, _ := ParseUUID("550e8400-e29b-41d4-a716-446655440000")
+	for _, src := range []any{
+		"550e8400-e29b-41d4-a716-446655440000",
+		"550e8400e29b41d4a716446655440000",
+		[]byte("550e8400-e29b-41d4-a716-446655440000"),
+		want[:],
+	} {
+		var u UUID
+		if err := u.Scan(src); err != nil {
+			t.Fatalf("Scan(%#v) error: %v", src, err)
+		}
+		if u != want {
+			t.Fatalf("Scan(%#v) = %v, want %v", src, u, want)
+		}
+	}
+
+	u := NewV4()
+	if err := u.Scan(nil); err != nil {
+		t.Fatalf("Scan(nil) error: %v", err)
+	}
+	if !u.IsZero() {
+		t.Fatalf("Scan(nil) = %v, want the nil UUID", u)
+	}
+}
+
+func TestUUID_ScanInvalid(t *testing.T) {
+	for _, src := range []any{"nope", []byte("550e8400-e29b-41d4-a716-44665544000g"), []byte{1, 2, 3}, 42} {
+		var u UUID
+		if err := u.Scan(src); err == nil {
+			t.Fatalf("Scan(%#v) expected error", src)
+		}
+	}
+}
+
+func TestUUID_ValueScanRoundTrip(t *testing.T) {
+	for _, binary := range []bool{false, true} {
+		SetSQLBinary(binary)
+		u := NewV7()
+		v, _ := u.Value()
+		var back UUID
+		if err := back.Scan(v); err != nil {
+			t.Fatalf("Scan error: %v", err)
+		}
+		if back != u {
+			t.Fatalf("round trip (binary=%v): got %v want %v", binary, back, u)
+		}
+	}
+	SetSQLBinary(false)
+}
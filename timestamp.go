@@ -0,0 +1,297 @@
+package uid
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math/big"
+	"strconv"
+	"time"
+)
+
+// v1Time returns the 60-bit Gregorian timestamp (100-ns intervals since
+// 1582-10-15) of a version 1 layout.
+func v1Time(b []byte) uint64 {
+	tl := uint64(binary.BigEndian.Uint32(b[0:4]))
+	tm := uint64(binary.BigEndian.Uint16(b[4:6]))
+	th := uint64(binary.BigEndian.Uint16(b[6:8]) & 0x0FFF)
+	return th<<48 | tm<<32 | tl
+}
+
+// v6Time returns the 60-bit Gregorian timestamp of a version 6 layout.
+func v6Time(b []byte) uint64 {
+	th := uint64(binary.BigEndian.Uint32(b[0:4]))
+	tm := uint64(binary.BigEndian.Uint16(b[4:6]))
+	tl := uint64(binary.BigEndian.Uint16(b[6:8]) & 0x0FFF)
+	return th<<28 | tm<<12 | tl
+}
+
+// v7Millis returns the 48-bit Unix millisecond timestamp of a version 7
+// layout.
+func v7Millis(b []byte) uint64 {
+	return uint64(b[0])<<40 | uint64(b[1])<<32 | uint64(b[2])<<24 |
+		uint64(b[3])<<16 | uint64(b[4])<<8 | uint64(b[5])
+}
+
+// gregorianTime converts 100-ns intervals since 1582-10-15 to a UTC time.
+func gregorianTime(t uint64) time.Time {
+	unix100ns := int64(t) - int64(gregorianToUnix100ns)
+	return time.Unix(unix100ns/1e7, unix100ns%1e7*100).UTC()
+}
+
+// gregorian100ns converts t to 100-ns intervals since 1582-10-15, the 60-bit
+// timestamp of version 1 and 6 UUIDs.
+func gregorian100ns(t time.Time) (uint64, error) {
+	unix100ns := t.Unix()*1e7 + int64(t.Nanosecond()/100)
+	if unix100ns < -int64(gregorianToUnix100ns) {
+		return 0, errors.New("time must not be before the Gregorian epoch (1582-10-15)")
+	}
+	ts := uint64(unix100ns + int64(gregorianToUnix100ns))
+	if ts >= 1<<60 {
+		return 0, errors.New("time exceeds the 60-bit UUID timestamp range")
+	}
+	return ts, nil
+}
+
+// TimeFromV6 returns the time embedded in a version 6 UUID.
+//
+// Example: 1ef2d0c4-62c5-6b2c-9c3b-6a6c7a9d5e12 => 2024-06-18T00:47:01.1944236Z
+//
+// Parameters:
+// - s: a version 6 UUID in any form accepted by ParseWithFormat
+//
+// Returns:
+// - The embedded time (UTC, 100-ns resolution), or an error if s is invalid
+// or not a version 6 UUID
+func TimeFromV6(s string) (time.Time, error) {
+	b, _, err := ParseWithFormat(s)
+	if err != nil {
+		return time.Time{}, err
+	}
+	if b[6]>>4 != 6 {
+		return time.Time{}, errors.New("not a version 6 UUID")
+	}
+	return gregorianTime(v6Time(b)), nil
+}
+
+// ExtractTimestamp returns the generation time embedded in a time-based
+// UUID, detecting the version: Gregorian 100-ns intervals for v1 and v6,
+// Unix milliseconds for v7.
+//
+// Example: ExtractTimestamp("1ef2d0c4-62c5-6b2c-9c3b-6a6c7a9d5e12") => 2024-06-18T00:47:01.1944236Z
+//
+// Parameters:
+// - s: a version 1, 6 or 7 UUID in any form accepted by ParseWithFormat
+//
+// Returns:
+// - The embedded time (UTC)
+// - An error if s is invalid or of a version without a timestamp (3, 4, 5
+// and 8)
+func ExtractTimestamp(s string) (time.Time, error) {
+	return parseTime(s)
+}
+
+// TimeFromV7 returns the time embedded in a version 7 UUID.
+//
+// Example: 01890a5d-ac96-774b-bcce-b302099a8057 => 2023-06-30T03:34:18.518Z
+//
+// Parameters:
+// - s: a version 7 UUID in any form accepted by ParseWithFormat
+//
+// Returns:
+// - The embedded time (UTC, millisecond resolution), or an error if s is
+// invalid or not a version 7 UUID
+func TimeFromV7(s string) (time.Time, error) {
+	b, _, err := ParseWithFormat(s)
+	if err != nil {
+		return time.Time{}, err
+	}
+	if b[6]>>4 != 7 {
+		return time.Time{}, errors.New("not a version 7 UUID")
+	}
+	return time.UnixMilli(int64(v7Millis(b))).UTC(), nil
+}
+
+// NewV7WithTime generates a version 7 UUID and returns it together with the
+// time embedded in it, so callers can log both without parsing the ID
+// again.
+//
+// Example: NewV7WithTime() => "01890a5dac96774bbcceb302099a8057", 2023-06-30T03:34:18.518Z
+//
+// Parameters:
+// - formatted: when true, include hyphens
+//
+// Returns:
+// - The UUID v7 as a string
+// - The embedded time (UTC, millisecond resolution); TimeFromV7 on the
+// returned string yields the same value
+func NewV7WithTime(formatted ...bool) (string, time.Time) {
+	b := newV7()
+	withHyphens := len(formatted) > 0 && formatted[0]
+	return bytesToUUIDString(b, withHyphens), time.UnixMilli(int64(v7Millis(b))).UTC()
+}
+
+// ValidateV7Skew checks on ingest that the time embedded in a version 7
+// UUID is close to the server clock, to reject clock-skewed or replayed
+// clients.
+//
+// Example: ValidateV7Skew(id, 5*time.Minute, 30*time.Second)
+//
+// Parameters:
+// - s: a version 7 UUID in any form accepted by ParseWithFormat
+// - past: how far the embedded time may lie before now, must not be negative
+// - future: how far the embedded time may lie after now, must not be negative
+//
+// Returns:
+// - nil if the embedded time is within [now-past, now+future]
+// - An error describing the skew, or why s or the window is invalid
+func ValidateV7Skew(s string, past, future time.Duration) error {
+	if past < 0 || future < 0 {
+		return errors.New("skew window must not be negative")
+	}
+	t, err := TimeFromV7(s)
+	if err != nil {
+		return err
+	}
+	now := nowFunc()
+	if skew := now.Sub(t); skew > past {
+		return fmt.Errorf("UUID time %s is %s before now, more than the allowed %s", t.Format(time.RFC3339Nano), skew, past)
+	}
+	if skew := t.Sub(now); skew > future {
+		return fmt.Errorf("UUID time %s is %s after now, more than the allowed %s", t.Format(time.RFC3339Nano), skew, future)
+	}
+	return nil
+}
+
+// Rekey returns a version 7 UUID carrying time t and the low 74 bits of s,
+// so a migrated record can be re-sorted by business time while keeping
+// the random part of its identity.
+//
+// Bytes 6-15 of s are kept apart from the version and variant bits, which
+// are overwritten; the 48-bit timestamp is replaced by t in Unix
+// milliseconds.
+//
+// Parameters:
+// - s: a UUID of any version in any form accepted by ParseWithFormat
+// - t: the time to embed, between the Unix epoch and year 10889
+// - formatted: when true, include hyphens
+//
+// Returns:
+// - The UUID v7 as a string, or an error
+func Rekey(s string, t time.Time, formatted ...bool) (string, error) {
+	b, _, err := ParseWithFormat(s)
+	if err != nil {
+		return "", err
+	}
+	ms := t.UnixMilli()
+	if ms < 0 || ms >= 1<<48 {
+		return "", errors.New("time must be between the Unix epoch and 2^48 milliseconds")
+	}
+	putMillis(b, uint64(ms))
+	setVersion(b, 7)
+	setVariantRFC4122(b)
+	withHyphens := len(formatted) > 0 && formatted[0]
+	return bytesToUUIDString(b, withHyphens), nil
+}
+
+// timeOf returns the timestamp embedded in a version 1, 6 or 7 UUID.
+func timeOf(b []byte) (time.Time, error) {
+	switch b[6] >> 4 {
+	case 1:
+		return gregorianTime(v1Time(b)), nil
+	case 6:
+		return gregorianTime(v6Time(b)), nil
+	case 7:
+		return time.UnixMilli(int64(v7Millis(b))).UTC(), nil
+	}
+	return time.Time{}, fmt.Errorf("version %d UUID has no timestamp", b[6]>>4)
+}
+
+// SameBucket reports whether the timestamps of two time-based UUIDs fall in
+// the same d-sized bucket, with buckets aligned to the Unix epoch.
+//
+// Example: with d = time.Minute, IDs from 12:00:05 and 12:00:59 share a
+// bucket; IDs from 12:00:59 and 12:01:00 do not.
+//
+// Parameters:
+// - a, b: version 1, 6 or 7 UUIDs in any form accepted by ParseWithFormat
+// - d: the bucket size, must be positive
+//
+// Returns:
+// - true if both timestamps fall in the same bucket
+// - An error if either UUID is invalid or not time-based, or d <= 0
+func SameBucket(a, b string, d time.Duration) (bool, error) {
+	if d <= 0 {
+		return false, errors.New("bucket size must be positive")
+	}
+	ta, err := parseTime(a)
+	if err != nil {
+		return false, err
+	}
+	tb, err := parseTime(b)
+	if err != nil {
+		return false, err
+	}
+	return bucketOf(ta, d).Cmp(bucketOf(tb, d)) == 0, nil
+}
+
+// parseTime parses s and returns its embedded timestamp.
+func parseTime(s string) (time.Time, error) {
+	b, _, err := ParseWithFormat(s)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return timeOf(b)
+}
+
+// bucketOf returns the index of the epoch-aligned d-sized bucket holding t.
+// It counts nanoseconds in a big.Int because t.UnixNano overflows outside
+// the years 1678 to 2262, which v1/v6 (from 1582) and v7 timestamps reach.
+func bucketOf(t time.Time, d time.Duration) *big.Int {
+	ns := new(big.Int).Mul(big.NewInt(t.Unix()), big.NewInt(int64(time.Second)))
+	ns.Add(ns, big.NewInt(int64(t.Nanosecond())))
+	// Euclidean division: the floor for a positive d, also before the epoch
+	return ns.Div(ns, big.NewInt(int64(d)))
+}
+
+// MillisHexPrefix returns a Unix millisecond timestamp as 12 lowercase hex
+// characters (48 bits, the timestamp field of a v7 UUID). Fixed width makes
+// the prefixes sort lexicographically in time order, so they suit log file
+// names and bucket keys. It does not sleep.
+//
+// Example: MillisHexPrefix(time.UnixMilli(1688096058518)) => "01890a5dac96"
+//
+// Parameters:
+// - t: the time to encode; the current time when omitted. Times before the
+// Unix epoch or past the 48-bit range (year 10889) are clamped
+//
+// Returns:
+// - The 12-character prefix
+func MillisHexPrefix(t ...time.Time) string {
+	now := nowFunc()
+	if len(t) > 0 {
+		now = t[0]
+	}
+	ms := min(max(now.UnixMilli(), 0), 1<<48-1)
+	return fmt.Sprintf("%012x", ms)
+}
+
+// TimeFromMillisHexPrefix decodes a prefix produced by MillisHexPrefix.
+//
+// Example: TimeFromMillisHexPrefix("01890a5dac96") => 2023-06-30T03:34:18.518Z
+//
+// Parameters:
+// - s: the 12-character hex prefix (case-insensitive)
+//
+// Returns:
+// - The time (UTC, millisecond resolution), or an error if s is invalid
+func TimeFromMillisHexPrefix(s string) (time.Time, error) {
+	if len(s) != 12 {
+		return time.Time{}, fmt.Errorf("invalid millisecond prefix length %d, want 12", len(s))
+	}
+	ms, err := strconv.ParseUint(s, 16, 64)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid millisecond prefix %q: %w", s, err)
+	}
+	return time.UnixMilli(int64(ms)).UTC(), nil
+}
@@ -0,0 +1,305 @@
+package uid
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRekey(t *testing.T) {
+	src := "550e8400-e29b-41d4-a716-446655440000"
+	at := time.Date(2019, 3, 14, 15, 9, 26, 535_000_000, time.UTC)
+
+	got, err := Rekey(src, at)
+	if err != nil {
+		t.Fatalf("Rekey error: %v", err)
+	}
+	assertLenAndVersion(t, got, 32, '7', false)
+
+	b, _, _ := ParseWithFormat(got)
+	if ms := v7Millis(b); int64(ms) != at.UnixMilli() {
+		t.Fatalf("Rekey time = %d ms, want %d", ms, at.UnixMilli())
+	}
+	// entropy tail: low nibble of byte 6, byte 7, low 6 bits of byte 8, bytes 9-15
+	if got[13:16] != "1d4" || got[17:] != "716446655440000" {
+		t.Fatalf("Rekey(%s) = %s, entropy tail not preserved", src, got)
+	}
+	if b[8]&0xC0 != 0x80 {
+		t.Fatalf("Rekey variant bits = %08b, want 10xxxxxx", b[8])
+	}
+
+	later, err := Rekey(src, at.Add(time.Second), true)
+	if err != nil {
+		t.Fatalf("Rekey error: %v", err)
+	}
+	assertLenAndVersion(t, later, 36, '7', true)
+	if FastRemoveHyphens(later) <= got {
+		t.Fatalf("Rekey with later time must sort after: %s <= %s", later, got)
+	}
+}
+
+func TestRekey_Invalid(t *testing.T) {
+	if _, err := Rekey("invalid", time.Now()); err == nil {
+		t.Fatal("Rekey expected error for invalid UUID")
+	}
+	if _, err := Rekey(UuidV4(), time.Unix(-1, 0)); err == nil {
+		t.Fatal("Rekey expected error for time before the Unix epoch")
+	}
+}
+
+func TestSameBucket(t *testing.T) {
+	base := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	at := func(offset time.Duration) string {
+		s, err := Rekey(UuidV4(), base.Add(offset))
+		if err != nil {
+			t.Fatalf("Rekey error: %v", err)
+		}
+		return s
+	}
+
+	cases := []struct {
+		a, b time.Duration
+		want bool
+	}{
+		{0, 59*time.Second + 999*time.Millisecond, true},
+		{59*time.Second + 999*time.Millisecond, time.Minute, false},
+		{-time.Millisecond, 0, false},
+		{time.Minute, 2*time.Minute - time.Millisecond, true},
+	}
+	for _, c := range cases {
+		got, err := SameBucket(at(c.a), at(c.b), time.Minute)
+		if err != nil {
+			t.Fatalf("SameBucket error: %v", err)
+		}
+		if got != c.want {
+			t.Fatalf("SameBucket(+%v, +%v, 1m) = %v, want %v", c.a, c.b, got, c.want)
+		}
+	}
+
+	if _, err := SameBucket(UuidV1(), UuidV6(true), time.Hour); err != nil {
+		t.Fatalf("SameBucket v1/v6 error: %v", err)
+	}
+}
+
+func TestSameBucket_BeforeUnixNanoRange(t *testing.T) {
+	// 1600 is before 1678, where time.UnixNano stops being defined
+	base := time.Date(1600, 1, 1, 0, 0, 0, 0, time.UTC)
+	at := func(version int, offset time.Duration) string {
+		ts, err := gregorian100ns(base.Add(offset))
+		if err != nil {
+			t.Fatalf("gregorian100ns error: %v", err)
+		}
+		b := make([]byte, 16)
+		if version == 6 {
+			putV6(b, ts, 0, []byte{1, 2, 3, 4, 5, 6})
+		} else {
+			putV1(b, ts, 0, []byte{1, 2, 3, 4, 5, 6})
+		}
+		return canonicalString(b)
+	}
+
+	cases := []struct {
+		a, b time.Duration
+		want bool
+	}{
+		{5 * time.Second, 59*time.Second + 999*time.Millisecond, true},
+		{59*time.Second + 999*time.Millisecond, time.Minute, false},
+		{-100 * time.Nanosecond, 0, false},
+	}
+	for _, version := range []int{1, 6} {
+		for _, c := range cases {
+			got, err := SameBucket(at(version, c.a), at(version, c.b), time.Minute)
+			if err != nil {
+				t.Fatalf("SameBucket error: %v", err)
+			}
+			if got != c.want {
+				t.Fatalf("v%d SameBucket(1600+%v, 1600+%v, 1m) = %v, want %v", version, c.a, c.b, got, c.want)
+			}
+		}
+	}
+}
+
+func TestSameBucket_Errors(t *testing.T) {
+	if _, err := SameBucket(UuidV4(), UuidV7(), time.Minute); err == nil {
+		t.Fatal("SameBucket expected error for a non-time-based UUID")
+	}
+	if _, err := SameBucket(UuidV7(), "invalid", time.Minute); err == nil {
+		t.Fatal("SameBucket expected error for an invalid UUID")
+	}
+	if _, err := SameBucket(UuidV7(), UuidV7(), 0); err == nil {
+		t.Fatal("SameBucket expected error for a zero bucket size")
+	}
+}
+
+func TestTimeFromV6_Invalid(t *testing.T) {
+	if _, err := TimeFromV6(UuidV1()); err == nil {
+		t.Fatal("TimeFromV6 expected error for a v1 UUID")
+	}
+	if _, err := TimeFromV6("invalid"); err == nil {
+		t.Fatal("TimeFromV6 expected error for an invalid UUID")
+	}
+}
+
+func TestTimeFromV7(t *testing.T) {
+	got, err := TimeFromV7("01890a5d-ac96-774b-bcce-b302099a8057")
+	if err != nil {
+		t.Fatalf("TimeFromV7 error: %v", err)
+	}
+	if want := time.UnixMilli(0x01890a5dac96).UTC(); !got.Equal(want) {
+		t.Fatalf("TimeFromV7 = %v, want %v", got, want)
+	}
+	if _, err := TimeFromV7(UuidV4()); err == nil {
+		t.Fatal("expected error for a version 4 UUID")
+	}
+	if _, err := TimeFromV7("nope"); err == nil {
+		t.Fatal("expected error for invalid input")
+	}
+}
+
+func TestNewV7WithTime(t *testing.T) {
+	for _, formatted := range []bool{false, true} {
+		s, ts := NewV7WithTime(formatted)
+		n := 32
+		if formatted {
+			n = 36
+		}
+		assertLenAndVersion(t, s, n, '7', formatted)
+		got, err := TimeFromV7(s)
+		if err != nil {
+			t.Fatalf("TimeFromV7(%q) error: %v", s, err)
+		}
+		if !got.Equal(ts) {
+			t.Fatalf("TimeFromV7(%q) = %v, want %v", s, got, ts)
+		}
+	}
+}
+
+func TestMillisHexPrefix(t *testing.T) {
+	at := time.UnixMilli(1688096058518)
+	if got := MillisHexPrefix(at); got != "01890a5dac96" {
+		t.Fatalf("MillisHexPrefix = %q, want %q", got, "01890a5dac96")
+	}
+	if got := MillisHexPrefix(time.Unix(-1, 0)); got != "000000000000" {
+		t.Fatalf("MillisHexPrefix before epoch = %q", got)
+	}
+	if got := len(MillisHexPrefix()); got != 12 {
+		t.Fatalf("len(MillisHexPrefix()) = %d, want 12", got)
+	}
+}
+
+func TestMillisHexPrefix_Ordering(t *testing.T) {
+	times := []time.Time{
+		time.Unix(0, 0),
+		time.Date(1999, 12, 31, 23, 59, 59, 999_000_000, time.UTC),
+		time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC),
+		time.Date(2024, 6, 18, 0, 47, 1, 194_000_000, time.UTC),
+		time.Date(2024, 6, 18, 0, 47, 1, 195_000_000, time.UTC),
+		time.Date(2300, 1, 1, 0, 0, 0, 0, time.UTC),
+	}
+	prev := ""
+	for _, at := range times {
+		p := MillisHexPrefix(at)
+		if p <= prev {
+			t.Fatalf("MillisHexPrefix(%v) = %q does not sort after %q", at, p, prev)
+		}
+		prev = p
+	}
+}
+
+func TestTimeFromMillisHexPrefix(t *testing.T) {
+	for _, at := range []time.Time{
+		time.Unix(0, 0).UTC(),
+		time.Date(2024, 6, 18, 0, 47, 1, 194_000_000, time.UTC),
+		time.Date(2300, 1, 1, 0, 0, 0, 0, time.UTC),
+	} {
+		got, err := TimeFromMillisHexPrefix(MillisHexPrefix(at))
+		if err != nil {
+			t.Fatalf("TimeFromMillisHexPrefix error: %v", err)
+		}
+		if !got.Equal(at) {
+			t.Fatalf("round trip: got %v want %v", got, at)
+		}
+	}
+	if _, err := TimeFromMillisHexPrefix("01890A5DAC96"); err != nil {
+		t.Fatalf("uppercase prefix rejected: %v", err)
+	}
+	for _, s := range []string{"", "01890a5dac9", "01890a5dac9g", "+1890a5dac96"} {
+		if _, err := TimeFromMillisHexPrefix(s); err == nil {
+			t.Fatalf("TimeFromMillisHexPrefix(%q) expected error", s)
+		}
+	}
+}
+
+func TestValidateV7Skew(t *testing.T) {
+	now := time.Date(2024, 6, 18, 12, 0, 0, 0, time.UTC)
+	nowFunc = func() time.Time { return now }
+	defer func() { nowFunc = time.Now }()
+
+	at := func(d time.Duration) string {
+		s, err := Rekey(UuidV4(), now.Add(d))
+		if err != nil {
+			t.Fatalf("Rekey error: %v", err)
+		}
+		return s
+	}
+
+	for _, d := range []time.Duration{0, -5 * time.Minute, 30 * time.Second, -time.Second} {
+		if err := ValidateV7Skew(at(d), 5*time.Minute, 30*time.Second); err != nil {
+			t.Fatalf("offset %s rejected: %v", d, err)
+		}
+	}
+	if err := ValidateV7Skew(at(-5*time.Minute-time.Millisecond), 5*time.Minute, 30*time.Second); err == nil || !strings.Contains(err.Error(), "before now") {
+		t.Fatalf("too old: got %v", err)
+	}
+	if err := ValidateV7Skew(at(31*time.Second), 5*time.Minute, 30*time.Second); err == nil || !strings.Contains(err.Error(), "after now") {
+		t.Fatalf("too new: got %v", err)
+	}
+}
+
+func TestValidateV7Skew_Invalid(t *testing.T) {
+	if err := ValidateV7Skew(UuidV4(), time.Hour, time.Hour); err == nil {
+		t.Fatal("expected error for a version 4 UUID")
+	}
+	if err := ValidateV7Skew("nope", time.Hour, time.Hour); err == nil {
+		t.Fatal("expected error for invalid input")
+	}
+	if err := ValidateV7Skew(UuidV7(), -time.Second, time.Hour); err == nil {
+		t.Fatal("expected error for a negative window")
+	}
+}
+
+func TestExtractTimestamp(t *testing.T) {
+	for name, gen := range map[string]func(...bool) string{
+		"v1": UuidV1,
+		"v6": UuidV6,
+		"v7": UuidV7,
+	} {
+		for _, formatted := range []bool{false, true} {
+			s := gen(formatted)
+			got, err := ExtractTimestamp(s)
+			if err != nil {
+				t.Fatalf("%s: ExtractTimestamp(%q) error: %v", name, s, err)
+			}
+			if d := time.Since(got); d < -time.Second || d > time.Second {
+				t.Fatalf("%s: ExtractTimestamp(%q) = %v, more than a second from now", name, s, got)
+			}
+		}
+	}
+	got, err := ExtractTimestamp("1ef2d0c4-62c5-6b2c-9c3b-6a6c7a9d5e12")
+	if err != nil {
+		t.Fatalf("ExtractTimestamp error: %v", err)
+	}
+	if want := time.Date(2024, 6, 18, 0, 47, 1, 194_423_600, time.UTC); !got.Equal(want) {
+		t.Fatalf("ExtractTimestamp = %v, want %v", got, want)
+	}
+}
+
+func TestExtractTimestamp_NoTimestamp(t *testing.T) {
+	v3, _ := UuidV3(NamespaceDNS, []byte("x"))
+	v5, _ := UuidV5(NamespaceDNS, []byte("x"))
+	for _, s := range []string{v3, UuidV4(), v5, "garbage"} {
+		if _, err := ExtractTimestamp(s); err == nil {
+			t.Fatalf("ExtractTimestamp(%q) expected error", s)
+		}
+	}
+}
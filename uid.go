@@ -1,12 +1,51 @@
 package uid
 
 import (
-	"crypto/rand"
+	"encoding/binary"
+	"fmt"
 	"strconv"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"time"
 )
 
+// nowFunc is the clock used by every time-based generator. Tests replace it
+// to get deterministic, strictly increasing timestamps.
+var nowFunc = time.Now
+
+// SetClock replaces the clock consulted by every time-based generator (the
+// time-prefixed IDs, Timestamp*, v1, v6, v7 and ULID), so tests can freeze
+// time and assert exact output. Like Reader it is not synchronized: set it
+// before generating concurrently.
+//
+// The monotonic guards still apply: with a frozen clock, consecutive IDs
+// continue from the previous one instead of repeating, and a clock set
+// behind IDs already handed out continues after them.
+//
+// Example: SetClock(func() time.Time { return time.Date(2025, 8, 31, 15, 11, 33, 0, time.UTC) })
+//
+// Parameters:
+// - now: the clock; nil restores the real clock (time.Now)
+func SetClock(now func() time.Time) {
+	if now == nil {
+		now = time.Now
+	}
+	nowFunc = now
+}
+
+// uidTimeLayout is the UTC timestamp prefix of the time-prefixed IDs. With
+// the dot removed it yields 21 digits, down to 100-nanosecond precision.
+const uidTimeLayout = "20060102150405.0000000"
+
+// Lengths of the time-prefixed IDs (unformatted).
+const (
+	humanUidLength = 32
+	nanoUidLength  = 23
+	microUidLength = 20
+	secUidLength   = 14
+)
+
 // HumanUid generates a 32-character time-prefixed unique ID.
 //
 // Format (conceptual): YYYYMMDDHHMMSSMMMMMMM + random suffix, truncated to 32.
@@ -20,15 +59,7 @@ import (
 // Returns:
 // - A 32-character uppercase numeric string suitable for human-readable IDs
 func HumanUid(formatted ...bool) string {
-	time.Sleep(1 * time.Nanosecond)
-
-	r, _ := rand.Prime(rand.Reader, 64)
-
-	id := time.Now().UTC().Format("20060102150405.0000000")
-	id = strings.ReplaceAll(id, ".", "")
-	id += r.String()
-
-	s := id[0:32]
+	s := newUid(humanUidLength, time.Nanosecond)
 	withHyphens := len(formatted) > 0 && formatted[0]
 	if withHyphens {
 		return formatWithHyphens(s, []int{8, 4, 4, 16})
@@ -49,15 +80,7 @@ func HumanUid(formatted ...bool) string {
 // Returns:
 // - A 23-character numeric string
 func NanoUid(formatted ...bool) string {
-	time.Sleep(time.Nanosecond) // as its a nanoseconds based ID we need at least a nanosecond between the generations to avoid collisions
-
-	r, _ := rand.Prime(rand.Reader, 64)
-
-	id := time.Now().UTC().Format("20060102150405.0000000")
-	id = strings.ReplaceAll(id, ".", "")
-	id += r.String()
-
-	s := id[0:23]
+	s := newUid(nanoUidLength, time.Nanosecond)
 	withHyphens := len(formatted) > 0 && formatted[0]
 	if withHyphens {
 		return formatWithHyphens(s, []int{8, 6, 6, 3})
@@ -78,15 +101,7 @@ func NanoUid(formatted ...bool) string {
 // Returns:
 // - A 20-character numeric string
 func MicroUid(formatted ...bool) string {
-	time.Sleep(time.Microsecond) // as its a microseconds based ID we need at least a microsecond between the generations to avoid collisions
-
-	r, _ := rand.Prime(rand.Reader, 64)
-
-	id := time.Now().UTC().Format("20060102150405.0000000")
-	id = strings.ReplaceAll(id, ".", "")
-	id += r.String()
-
-	s := id[0:20]
+	s := newUid(microUidLength, time.Microsecond)
 	withHyphens := len(formatted) > 0 && formatted[0]
 	if withHyphens {
 		return formatWithHyphens(s, []int{8, 6, 6})
@@ -107,22 +122,279 @@ func MicroUid(formatted ...bool) string {
 // Returns:
 // - A 14-character numeric string representing UTC date/time to the second
 func SecUid(formatted ...bool) string {
-	time.Sleep(time.Second) // as its a seconds based ID we need at least a second between the generations to avoid collisions
+	s := newUid(secUidLength, time.Second)
+	withHyphens := len(formatted) > 0 && formatted[0]
+	if withHyphens {
+		return formatWithHyphens(s, []int{8, 6})
+	}
+	return s
+}
 
-	r, _ := rand.Prime(rand.Reader, 64)
+// SecUidFast is SecUid without the one-second sleep, for request paths.
+//
+// A SecUid has no random digits, so uniqueness cannot come from
+// randomness. Instead, IDs are strictly increasing process-wide, as under
+// UidStrategyMonotonic: when several IDs are requested within one second,
+// each is one second after the previous one, carrying into the minute,
+// hour and date like a clock, so a burst runs ahead of the clock (100 IDs
+// in one second end up 100 seconds ahead) but every ID is a valid time.
+// Processes generating concurrently can still collide; use a UUID where
+// that matters.
+//
+// Parameters:
+// - formatted: when true, include hyphens in groups 8-6 (length becomes 15)
+//
+// Returns:
+// - A 14-character numeric string
+func SecUidFast(formatted ...bool) string {
+	s := newUidWith(UidStrategyMonotonic, secUidLength, 0)
+	withHyphens := len(formatted) > 0 && formatted[0]
+	if withHyphens {
+		return formatWithHyphens(s, []int{8, 6})
+	}
+	return s
+}
 
-	id := time.Now().UTC().Format("20060102150405.0000000")
-	id = strings.ReplaceAll(id, ".", "")
-	id += r.String()
+// MicroUidFast is MicroUid without the sleep. Like SecUidFast it relies on
+// a process-wide monotonic guard rather than randomness, since a MicroUid
+// is fully consumed by its timestamp; bursts of more than one ID per
+// microsecond run ahead of the clock.
+//
+// Parameters:
+// - formatted: when true, include hyphens in groups 8-6-6 (length becomes 22)
+//
+// Returns:
+// - A 20-character numeric string
+func MicroUidFast(formatted ...bool) string {
+	s := newUidWith(UidStrategyMonotonic, microUidLength, 0)
+	withHyphens := len(formatted) > 0 && formatted[0]
+	if withHyphens {
+		return formatWithHyphens(s, []int{8, 6, 6})
+	}
+	return s
+}
 
-	s := id[0:14]
+// NanoUidFast is NanoUid without the sleep. Like SecUidFast it relies on a
+// process-wide monotonic guard, since only two random digits follow the
+// 100-ns timestamp; bursts run ahead of the clock.
+//
+// Parameters:
+// - formatted: when true, include hyphens in groups 8-6-6-3 (length becomes 26)
+//
+// Returns:
+// - A 23-character numeric string
+func NanoUidFast(formatted ...bool) string {
+	s := newUidWith(UidStrategyMonotonic, nanoUidLength, 0)
 	withHyphens := len(formatted) > 0 && formatted[0]
 	if withHyphens {
-		return formatWithHyphens(s, []int{8, 6})
+		return formatWithHyphens(s, []int{8, 6, 6, 3})
+	}
+	return s
+}
+
+// SecUidN returns count strictly increasing SecUids without sleeping, for
+// seeding tables. Like SecUidFast it relies on the monotonic guard, so a
+// batch runs ahead of the clock by one second per ID beyond the first,
+// carrying into the minute, hour and date like a clock.
+//
+// Parameters:
+// - count: the number of IDs; count <= 0 yields an empty slice
+// - formatted: when true, include hyphens in groups 8-6
+//
+// Returns:
+// - The IDs in ascending order
+func SecUidN(count int, formatted ...bool) []string {
+	return uidN("sec", count, formatted...)
+}
+
+// MicroUidN returns count strictly increasing MicroUids without sleeping,
+// for seeding tables: 50,000 IDs take milliseconds. A batch runs ahead of
+// the clock by up to one microsecond per ID beyond the first; every ID is
+// still a valid time.
+//
+// Parameters:
+// - count: the number of IDs; count <= 0 yields an empty slice
+// - formatted: when true, include hyphens in groups 8-6-6
+//
+// Returns:
+// - The IDs in ascending order
+func MicroUidN(count int, formatted ...bool) []string {
+	return uidN("micro", count, formatted...)
+}
+
+// NanoUidN returns count strictly increasing NanoUids without sleeping,
+// for seeding tables.
+//
+// Parameters:
+// - count: the number of IDs; count <= 0 yields an empty slice
+// - formatted: when true, include hyphens in groups 8-6-6-3
+//
+// Returns:
+// - The IDs in ascending order
+func NanoUidN(count int, formatted ...bool) []string {
+	return uidN("nano", count, formatted...)
+}
+
+// uidN implements the *UidN functions for a kind of uidKinds.
+func uidN(kind string, count int, formatted ...bool) []string {
+	if count <= 0 {
+		return []string{}
+	}
+	k := uidKinds[kind]
+	withHyphens := len(formatted) > 0 && formatted[0]
+	ids := make([]string, count)
+	for i := range ids {
+		ids[i] = newUidWith(UidStrategyMonotonic, k.length, 0)
+		if withHyphens {
+			ids[i] = formatWithHyphens(ids[i], k.groups)
+		}
+	}
+	return ids
+}
+
+// Collision-avoidance strategies of the time-prefixed IDs, see
+// SetUidStrategy.
+const (
+	UidStrategySleep     = "sleep"
+	UidStrategyMonotonic = "monotonic"
+	UidStrategyRandom    = "random"
+)
+
+var (
+	uidMu       sync.Mutex
+	uidStrategy = UidStrategySleep
+	uidLast     = map[int]string{} // last ID per length, for the sleep and monotonic strategies
+)
+
+// SetUidStrategy selects how HumanUid, NanoUid, MicroUid and SecUid avoid
+// collisions:
+//
+//   - "sleep" (default): each call first sleeps for one unit of the ID's
+//     time resolution (up to a full second for SecUid), which keeps the
+//     timestamps close to real time, and IDs are strictly increasing
+//     process-wide as under "monotonic", so ordering never depends on the
+//     sleep. Throughput per goroutine is bounded by the sleep.
+//   - "monotonic": no sleep; IDs are strictly increasing process-wide. When
+//     the clock has not advanced, the previous ID's random suffix plus one
+//     is returned or, without a suffix to spare, its timestamp one unit of
+//     resolution later, so bursts run ahead of the clock but every ID is
+//     still a valid time. Callers serialize on a mutex.
+//   - "random": no sleep and no ordering guarantee; uniqueness relies only
+//     on the timestamp and the random suffix (none for MicroUid and SecUid,
+//     which are fully consumed by the timestamp). Highest throughput.
+//
+// Parameters:
+// - strategy: one of UidStrategySleep, UidStrategyMonotonic or UidStrategyRandom
+//
+// Returns:
+// - An error for an unknown strategy
+func SetUidStrategy(strategy string) error {
+	switch strategy {
+	case UidStrategySleep, UidStrategyMonotonic, UidStrategyRandom:
+	default:
+		return fmt.Errorf("unknown Uid strategy %q", strategy)
+	}
+	uidMu.Lock()
+	uidStrategy = strategy
+	uidMu.Unlock()
+	return nil
+}
+
+// newUid builds an unformatted time-prefixed ID of the given length under
+// the current strategy. pause is the resolution of the ID, slept by the
+// "sleep" strategy so consecutive generations do not collide.
+func newUid(length int, pause time.Duration) string {
+	uidMu.Lock()
+	strategy := uidStrategy
+	uidMu.Unlock()
+	return newUidWith(strategy, length, pause)
+}
+
+// newUidWith is newUid with an explicit strategy.
+func newUidWith(strategy string, length int, pause time.Duration) string {
+	if strategy == UidStrategySleep {
+		time.Sleep(pause)
+	}
+
+	id := nowFunc().UTC().Format(uidTimeLayout)
+	id = strings.ReplaceAll(id, ".", "")
+	if len(id) < length {
+		// fixed width: a small random value keeps its leading zeros
+		id += randomDigits(length - len(id))
+	}
+
+	s := id[0:length]
+	if strategy != UidStrategyRandom {
+		// a per-process guard: when the clock has not advanced, continue
+		// from the last ID so IDs are strictly increasing
+		uidMu.Lock()
+		if last := uidLast[length]; s <= last {
+			s = nextUid(last)
+		}
+		uidLast[length] = s
+		uidMu.Unlock()
 	}
 	return s
 }
 
+// randomDigits returns n uniformly random decimal digits. Each 64-bit draw
+// yields 9 digits; the modulo bias of 10^9 over 2^64 (about 5e-11) is
+// negligible.
+func randomDigits(n int) string {
+	b := make([]byte, n)
+	var r [8]byte
+	for i := 0; i < n; i += 9 {
+		if err := readRandom(r[:]); err != nil {
+			// fallback
+			binary.BigEndian.PutUint64(r[:], uint64(time.Now().UnixNano()))
+		}
+		v := binary.BigEndian.Uint64(r[:])
+		for j := i; j < min(i+9, n); j++ {
+			b[j] = '0' + byte(v%10)
+			v /= 10
+		}
+	}
+	return string(b)
+}
+
+// nextUid returns the smallest time-prefixed ID after last that is still
+// a valid timestamp: the random suffix plus one or, once the suffix is
+// exhausted (or absent), the timestamp one unit of its resolution later
+// with a zero suffix. The seconds thus carry into the minute as a clock
+// would, instead of counting through 60..99.
+func nextUid(last string) string {
+	timeDigits := min(len(last), len(strings.ReplaceAll(uidTimeLayout, ".", "")))
+	if suffix := last[timeDigits:]; suffix != "" {
+		if next := incrementDecimal(suffix); next > suffix {
+			return last[:timeDigits] + next
+		}
+	}
+
+	padded := last[:timeDigits] + strings.Repeat("0", len(uidTimeLayout)-1-timeDigits)
+	t, err := time.Parse(uidTimeLayout, padded[:secUidLength]+"."+padded[secUidLength:])
+	if err != nil {
+		// not produced by this package; keep the IDs increasing
+		return incrementDecimal(last)
+	}
+	next := t.Add(uidResolution(len(last))).Format(uidTimeLayout)
+	next = strings.ReplaceAll(next, ".", "")[:timeDigits]
+	return next + strings.Repeat("0", len(last)-timeDigits)
+}
+
+// incrementDecimal adds one to a string of decimal digits, keeping its
+// length.
+func incrementDecimal(s string) string {
+	b := []byte(s)
+	for i := len(b) - 1; i >= 0; i-- {
+		if b[i] < '9' {
+			b[i]++
+			break
+		}
+		b[i] = '0'
+	}
+	return string(b)
+}
+
 // Timestamp returns the current Unix timestamp in seconds as a string.
 //
 // Example: 1725111153 (length: 10)
@@ -132,9 +404,12 @@ func SecUid(formatted ...bool) string {
 //
 // Returns:
 // - Unix timestamp in seconds (base-10 string)
+//
+// Concurrent callers never receive the same value: if the clock has not
+// advanced past the last value handed out, that value plus one is returned.
 func Timestamp() string {
 	time.Sleep(time.Second) // as its a seconds based ID we need at least a second between the generations to avoid collisions
-	now := time.Now().UTC().Unix()
+	now := nextTimestamp(&lastTimestamp, nowFunc().UTC().Unix())
 	return strconv.FormatInt(now, 10)
 }
 
@@ -147,10 +422,12 @@ func Timestamp() string {
 //
 // Returns:
 // - Unix timestamp in microseconds (base-10 string)
+//
+// Concurrent callers never receive the same value, see Timestamp.
 func TimestampMicro() string {
 	time.Sleep(time.Microsecond) // as its a microseconds based ID we need at least a microsecond between the generations to avoid collisions
 
-	now := time.Now().UTC().UnixMicro()
+	now := nextTimestamp(&lastTimestampMicro, nowFunc().UTC().UnixMicro())
 
 	return strconv.FormatInt(now, 10)
 }
@@ -164,14 +441,34 @@ func TimestampMicro() string {
 //
 // Returns:
 // - Unix timestamp in nanoseconds (base-10 string)
+//
+// Concurrent callers never receive the same value, see Timestamp.
 func TimestampNano() string {
 	time.Sleep(time.Nanosecond) // as its a nanoseconds based ID we need at least a nanosecond between the generations to avoid collisions
 
-	now := time.Now().UTC().UnixNano()
+	now := nextTimestamp(&lastTimestampNano, nowFunc().UTC().UnixNano())
 
 	return strconv.FormatInt(now, 10)
 }
 
+// Last values handed out by the Timestamp functions.
+var lastTimestamp, lastTimestampMicro, lastTimestampNano atomic.Int64
+
+// nextTimestamp returns now, or last+1 if the clock has not advanced past
+// the last value handed out, and records the result in last.
+func nextTimestamp(last *atomic.Int64, now int64) int64 {
+	for {
+		prev := last.Load()
+		next := now
+		if next <= prev {
+			next = prev + 1
+		}
+		if last.CompareAndSwap(prev, next) {
+			return next
+		}
+	}
+}
+
 // formatWithHyphens inserts hyphens into s grouped by the provided sizes.
 // Example: formatWithHyphens("20171119084926659914", []int{8,6,6}) => "20171119-084926-659914".
 func formatWithHyphens(s string, groups []int) string {
@@ -1,9 +1,29 @@
 package uid
 
 import (
+	"crypto/rand"
+	"strings"
+	"sync"
 	"testing"
+	"time"
 )
 
+// useSteppingClock replaces nowFunc with a clock that advances by step on
+// every call, so ordering assertions do not depend on the resolution of the
+// machine clock. The real clock is restored when the test ends.
+func useSteppingClock(t *testing.T, step time.Duration) {
+	t.Helper()
+	var mu sync.Mutex
+	current := time.Now()
+	nowFunc = func() time.Time {
+		mu.Lock()
+		defer mu.Unlock()
+		current = current.Add(step)
+		return current
+	}
+	t.Cleanup(func() { nowFunc = time.Now })
+}
+
 // helper to assert expected length and hyphen positions
 func assertHyphenPositions(t *testing.T, s string, wantLen int, positions []int) {
 	t.Helper()
@@ -21,6 +41,8 @@ func assertHyphenPositions(t *testing.T, s string, wantLen int, positions []int)
 }
 
 func TestHumanUid(t *testing.T) {
+	useSteppingClock(t, time.Microsecond)
+
 	humanUid := HumanUid()
 	humanUid2 := HumanUid()
 
@@ -51,6 +73,8 @@ func TestHumanUidFormatted(t *testing.T) {
 }
 
 func TestMicroUid(t *testing.T) {
+	useSteppingClock(t, time.Microsecond)
+
 	microUid := MicroUid()
 	microUid2 := MicroUid()
 
@@ -81,6 +105,8 @@ func TestMicroUidFormatted(t *testing.T) {
 }
 
 func TestNanoUid(t *testing.T) {
+	useSteppingClock(t, time.Microsecond)
+
 	nanoUid := NanoUid()
 	nanoUid2 := NanoUid()
 
@@ -111,8 +137,9 @@ func TestNanoUidFormatted(t *testing.T) {
 }
 
 func TestSecUid(t *testing.T) {
+	useSteppingClock(t, time.Second)
+
 	secUid := SecUid()
-	// time.Sleep(time.Second) // as its a seconds based ID we need at least a second between the generation
 	secUid2 := SecUid()
 
 	if secUid == "" {
@@ -142,6 +169,8 @@ func TestSecUidFormatted(t *testing.T) {
 }
 
 func TestTimestamp(t *testing.T) {
+	useSteppingClock(t, time.Second)
+
 	ts1 := Timestamp()
 	ts2 := Timestamp()
 
@@ -163,6 +192,8 @@ func TestTimestamp(t *testing.T) {
 }
 
 func TestTimestampMicro(t *testing.T) {
+	useSteppingClock(t, time.Microsecond)
+
 	ts1 := TimestampMicro()
 	ts2 := TimestampMicro()
 
@@ -184,6 +215,8 @@ func TestTimestampMicro(t *testing.T) {
 }
 
 func TestTimestampNano(t *testing.T) {
+	useSteppingClock(t, time.Microsecond)
+
 	ts1 := TimestampNano()
 	ts2 := TimestampNano()
 
@@ -203,3 +236,411 @@ func TestTimestampNano(t *testing.T) {
 		t.Fatal("Timestamp 1 must be smaller than Timestamp 2")
 	}
 }
+
+func TestTimestamps_ConcurrentDistinct(t *testing.T) {
+	generators := map[string]func() string{
+		"Timestamp":      Timestamp,
+		"TimestampMicro": TimestampMicro,
+		"TimestampNano":  TimestampNano,
+	}
+	for name, generate := range generators {
+		const goroutines = 200
+		results := make(chan string, goroutines)
+		var wg sync.WaitGroup
+		for i := 0; i < goroutines; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				results <- generate()
+			}()
+		}
+		wg.Wait()
+		close(results)
+
+		seen := make(map[string]bool, goroutines)
+		for ts := range results {
+			if seen[ts] {
+				t.Fatalf("%s returned duplicate value %s", name, ts)
+			}
+			seen[ts] = true
+		}
+	}
+}
+
+func TestSetUidStrategy(t *testing.T) {
+	t.Cleanup(func() { _ = SetUidStrategy(UidStrategySleep) })
+
+	if err := SetUidStrategy("bogus"); err == nil {
+		t.Fatal("SetUidStrategy expected error for unknown strategy")
+	}
+
+	// monotonic: strictly increasing without sleeping, even for SecUid
+	if err := SetUidStrategy(UidStrategyMonotonic); err != nil {
+		t.Fatalf("SetUidStrategy error: %v", err)
+	}
+	generators := map[string]func(...bool) string{
+		"HumanUid": HumanUid,
+		"NanoUid":  NanoUid,
+		"MicroUid": MicroUid,
+		"SecUid":   SecUid,
+	}
+	for name, generate := range generators {
+		prev := generate()
+		for i := 0; i < 1000; i++ {
+			next := generate()
+			if next <= prev {
+				t.Fatalf("%s not strictly increasing with monotonic strategy: %s <= %s", name, next, prev)
+			}
+			prev = next
+		}
+	}
+
+	// random: no sleep, uniqueness from the random suffix
+	if err := SetUidStrategy(UidStrategyRandom); err != nil {
+		t.Fatalf("SetUidStrategy error: %v", err)
+	}
+	seen := make(map[string]bool)
+	for i := 0; i < 1000; i++ {
+		id := HumanUid()
+		if len(id) != humanUidLength {
+			t.Fatalf("HumanUid length = %d with random strategy", len(id))
+		}
+		if seen[id] {
+			t.Fatalf("HumanUid duplicate with random strategy: %s", id)
+		}
+		seen[id] = true
+	}
+
+	// sleep: the default behavior
+	if err := SetUidStrategy(UidStrategySleep); err != nil {
+		t.Fatalf("SetUidStrategy error: %v", err)
+	}
+	a, b := MicroUid(), MicroUid()
+	if a >= b {
+		t.Fatalf("MicroUid not increasing with sleep strategy: %s >= %s", a, b)
+	}
+}
+
+func TestIncrementDecimal(t *testing.T) {
+	cases := map[string]string{"0": "1", "19": "20", "0999": "1000", "20250831151133": "20250831151134"}
+	for in, want := range cases {
+		if got := incrementDecimal(in); got != want {
+			t.Fatalf("incrementDecimal(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+// useFreshUidGuard clears the monotonic guard's memory of the IDs handed
+// out so far, so a test can freeze the clock anywhere; the previous state is
+// restored when the test ends.
+func useFreshUidGuard(t *testing.T) {
+	t.Helper()
+	uidMu.Lock()
+	saved := uidLast
+	uidLast = map[int]string{}
+	uidMu.Unlock()
+	t.Cleanup(func() {
+		uidMu.Lock()
+		uidLast = saved
+		uidMu.Unlock()
+	})
+}
+
+// parseUidTime parses the timestamp prefix of a time-prefixed ID.
+func parseUidTime(t *testing.T, id string) time.Time {
+	t.Helper()
+	digits := strings.ReplaceAll(id, "-", "")
+	digits = digits[:min(len(digits), 21)]
+	digits += strings.Repeat("0", 21-len(digits))
+	parsed, err := time.Parse(uidTimeLayout, digits[:14]+"."+digits[14:])
+	if err != nil {
+		t.Fatalf("%q is not a valid timestamp: %v", id, err)
+	}
+	return parsed
+}
+
+func TestNextUid(t *testing.T) {
+	cases := map[string]string{
+		"20250831151158":          "20250831151159",
+		"20250831151159":          "20250831151200",
+		"20251231235959":          "20260101000000",
+		"20250831151159999999":    "20250831151200000000",
+		"20250831151159999999999": "20250831151200000000000",
+		"20250831151159999999942": "20250831151159999999943",
+		"20240228235959":          "20240229000000",
+	}
+	for in, want := range cases {
+		if got := nextUid(in); got != want {
+			t.Fatalf("nextUid(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestUidGuardCrossesMinute(t *testing.T) {
+	if err := SetUidStrategy(UidStrategyMonotonic); err != nil {
+		t.Fatalf("SetUidStrategy error: %v", err)
+	}
+	t.Cleanup(func() { SetUidStrategy(UidStrategySleep) })
+	useFreshUidGuard(t)
+	frozen := time.Date(2025, 8, 31, 15, 11, 58, 999999900, time.UTC)
+	SetClock(func() time.Time { return frozen })
+	t.Cleanup(func() { SetClock(nil) })
+
+	for _, g := range []struct {
+		name string
+		fn   func(...bool) string
+	}{
+		{"SecUid", SecUid},
+		{"MicroUid", MicroUid},
+		{"NanoUid", NanoUid},
+		{"HumanUid", HumanUid},
+	} {
+		prevID, prevTime := "", time.Time{}
+		for i := 0; i < 5; i++ {
+			id := g.fn()
+			at := parseUidTime(t, id)
+			if id <= prevID || at.Before(prevTime) {
+				t.Fatalf("%s not increasing at %d: %q after %q", g.name, i, id, prevID)
+			}
+			prevID, prevTime = id, at
+		}
+	}
+
+	if got := SecUid(); got != "20250831151203" {
+		t.Fatalf("SecUid() = %q after crossing the minute, want 20250831151203", got)
+	}
+}
+
+func TestUidFast(t *testing.T) {
+	generators := map[string]struct {
+		generate func(...bool) string
+		length   int
+		hyphens  []int
+	}{
+		"SecUidFast":   {SecUidFast, secUidLength, []int{8}},
+		"MicroUidFast": {MicroUidFast, microUidLength, []int{8, 15}},
+		"NanoUidFast":  {NanoUidFast, nanoUidLength, []int{8, 15, 22}},
+	}
+	for name, g := range generators {
+		start := time.Now()
+		prev := g.generate()
+		for i := 0; i < 1000; i++ {
+			next := g.generate()
+			if len(next) != g.length {
+				t.Fatalf("%s length = %d, want %d", name, len(next), g.length)
+			}
+			if next <= prev {
+				t.Fatalf("%s not strictly increasing: %s <= %s", name, next, prev)
+			}
+			prev = next
+		}
+		if elapsed := time.Since(start); elapsed > time.Second {
+			t.Fatalf("%s took %s for 1000 IDs; it must not sleep", name, elapsed)
+		}
+		assertHyphenPositions(t, g.generate(true), g.length+len(g.hyphens), g.hyphens)
+	}
+}
+
+func TestSecUidFast_BurstCarries(t *testing.T) {
+	useFreshUidGuard(t)
+	frozen := time.Date(2025, 8, 31, 15, 11, 58, 0, time.UTC)
+	SetClock(func() time.Time { return frozen })
+	t.Cleanup(func() { SetClock(nil) })
+
+	var last string
+	for i := 0; i < 100; i++ {
+		last = SecUidFast()
+		parseUidTime(t, last)
+	}
+	// 100 IDs within one frozen second: the last is 99 seconds ahead
+	if want := frozen.Add(99 * time.Second).Format("20060102150405"); last != want {
+		t.Fatalf("100th SecUidFast() = %q, want %q", last, want)
+	}
+}
+
+func TestRandomDigits(t *testing.T) {
+	for _, n := range []int{0, 1, 2, 9, 10, 11, 30} {
+		s := randomDigits(n)
+		if len(s) != n {
+			t.Fatalf("randomDigits(%d) length = %d", n, len(s))
+		}
+		for i := 0; i < len(s); i++ {
+			if s[i] < '0' || s[i] > '9' {
+				t.Fatalf("randomDigits(%d) = %q contains a non-digit", n, s)
+			}
+		}
+	}
+
+	// 20000 digits: each should appear about 2000 times
+	var counts [10]int
+	for i := 0; i < 2000; i++ {
+		for _, c := range randomDigits(10) {
+			counts[c-'0']++
+		}
+	}
+	for d, c := range counts {
+		if c < 1600 || c > 2400 {
+			t.Fatalf("digit %d drawn %d times of 20000, distribution %v", d, c, counts)
+		}
+	}
+}
+
+func BenchmarkUidSuffixPrime(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		r, _ := rand.Prime(rand.Reader, 64)
+		_ = r.String()
+	}
+}
+
+func BenchmarkUidSuffixDigits(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		_ = randomDigits(humanUidLength - 21) // the HumanUid suffix
+	}
+}
+
+func BenchmarkHumanUidRandomStrategy(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		_ = newUidWith(UidStrategyRandom, humanUidLength, 0)
+	}
+}
+
+func TestUid_ShortRandomSuffix(t *testing.T) {
+	readers := map[string]func(t *testing.T){
+		"zero": func(t *testing.T) { useFixedReader(t, make([]byte, 16)) },
+		"one":  func(t *testing.T) { useFixedReader(t, []byte{0, 0, 0, 0, 0, 0, 0, 1}) },
+		"failing": func(t *testing.T) {
+			prev := Reader
+			Reader = failingReader{}
+			t.Cleanup(func() { Reader = prev })
+		},
+	}
+	for name, use := range readers {
+		t.Run(name, func(t *testing.T) {
+			use(t)
+			for _, g := range []struct {
+				id     string
+				length int
+			}{
+				{HumanUid(), humanUidLength},
+				{NanoUid(), nanoUidLength},
+			} {
+				if len(g.id) != g.length || !IsNumericUid(g.id) {
+					t.Fatalf("got %q, want %d digits", g.id, g.length)
+				}
+			}
+		})
+	}
+
+	useFixedReader(t, make([]byte, 16))
+	useSteppingClock(t, time.Second) // the clock advances, so no guard increment
+	if id := HumanUid(); id[21:] != "00000000000" {
+		t.Fatalf("HumanUid suffix = %q, want zero-padded digits", id[21:])
+	}
+}
+
+func TestUid_StrictlyIncreasingWithoutClockAdvance(t *testing.T) {
+	useSteppingClock(t, 0) // frozen clock: the sleep cannot order the IDs
+
+	for _, gen := range []struct {
+		name string
+		fn   func(...bool) string
+	}{
+		{"HumanUid", HumanUid},
+		{"NanoUid", NanoUid},
+	} {
+		prev := gen.fn()
+		for i := 0; i < 100000; i++ {
+			id := gen.fn()
+			if id <= prev {
+				t.Fatalf("%s not strictly increasing at %d: %q after %q", gen.name, i, id, prev)
+			}
+			prev = id
+		}
+	}
+}
+
+func TestUidN(t *testing.T) {
+	for _, g := range []struct {
+		name   string
+		fn     func(int, ...bool) []string
+		length int
+		count  int
+	}{
+		// a SecUid batch runs one second ahead per ID, keep it short
+		{"SecUidN", SecUidN, secUidLength, 100},
+		{"MicroUidN", MicroUidN, microUidLength, 50000},
+		{"NanoUidN", NanoUidN, nanoUidLength, 50000},
+	} {
+		start := time.Now()
+		ids := g.fn(g.count)
+		if elapsed := time.Since(start); elapsed > 5*time.Second {
+			t.Fatalf("%s(%d) took %s", g.name, g.count, elapsed)
+		}
+		if len(ids) != g.count {
+			t.Fatalf("%s returned %d IDs", g.name, len(ids))
+		}
+		var prevTime time.Time
+		for i, id := range ids {
+			if len(id) != g.length || !IsNumericUid(id) {
+				t.Fatalf("%s: %q is not a %d-digit ID", g.name, id, g.length)
+			}
+			at := parseUidTime(t, id)
+			if i > 0 && id <= ids[i-1] {
+				t.Fatalf("%s not strictly increasing at %d: %q after %q", g.name, i, id, ids[i-1])
+			}
+			// a NanoUid has a random suffix, so its time may repeat
+			if i > 0 && (at.Before(prevTime) || g.length != nanoUidLength && !at.After(prevTime)) {
+				t.Fatalf("%s times not increasing at %d: %q after %q", g.name, i, id, ids[i-1])
+			}
+			prevTime = at
+		}
+
+		formatted := g.fn(3, true)
+		for _, id := range formatted {
+			if !strings.Contains(id, "-") {
+				t.Fatalf("%s(3, true) = %q, want hyphens", g.name, id)
+			}
+		}
+		if len(g.fn(0)) != 0 || len(g.fn(-1)) != 0 {
+			t.Fatalf("%s must return an empty slice for count <= 0", g.name)
+		}
+	}
+}
+
+func TestSecUidN_CrossesMinute(t *testing.T) {
+	useFreshUidGuard(t)
+	frozen := time.Date(2025, 8, 31, 15, 11, 58, 0, time.UTC)
+	SetClock(func() time.Time { return frozen })
+	t.Cleanup(func() { SetClock(nil) })
+
+	got := strings.Join(SecUidN(5), " ")
+	want := "20250831151158 20250831151159 20250831151200 20250831151201 20250831151202"
+	if got != want {
+		t.Fatalf("SecUidN(5) = %s, want %s", got, want)
+	}
+}
+
+func TestSetClock(t *testing.T) {
+	if err := SetUidStrategy(UidStrategyRandom); err != nil { // no guard, no sleep
+		t.Fatalf("SetUidStrategy error: %v", err)
+	}
+	t.Cleanup(func() { SetUidStrategy(UidStrategySleep) })
+	frozen := time.Date(2025, 8, 31, 15, 11, 33, 123456789, time.FixedZone("CEST", 2*3600))
+	SetClock(func() time.Time { return frozen })
+	t.Cleanup(func() { SetClock(nil) })
+
+	if got := SecUid(); got != "20250831131133" {
+		t.Fatalf("SecUid() = %q, want 20250831131133", got)
+	}
+	if got := MicroUid(); got != "20250831131133123456" {
+		t.Fatalf("MicroUid() = %q, want 20250831131133123456", got)
+	}
+	if got := NanoUid(); !strings.HasPrefix(got, "202508311311331234567") {
+		t.Fatalf("NanoUid() = %q, want the frozen time prefix", got)
+	}
+
+	SetClock(nil)
+	if got, today := SecUid(), time.Now().UTC().Format("20060102"); got[:8] != today {
+		t.Fatalf("SecUid() = %q after restoring the real clock, want %s", got, today)
+	}
+}
@@ -0,0 +1,128 @@
+package uid
+
+import (
+	"encoding/binary"
+	"fmt"
+	"time"
+)
+
+// lastUlid is the last ULID handed out by Ulid, guarded by mu.
+var lastUlid [16]byte
+
+// Ulid returns a new ULID: a 48-bit Unix millisecond timestamp followed by
+// 80 random bits, as 26 Crockford Base32 characters. ULIDs sort
+// lexicographically by time.
+//
+// As the ULID spec recommends, ULIDs are monotonic: while the millisecond
+// has not advanced past the previous ULID's, the previous random bits are
+// incremented instead of drawn again, so ULIDs are strictly increasing
+// process-wide.
+//
+// Example: 01H455VB4PEX5VSKNK084SN02Q (length: 26)
+func Ulid() string {
+	ms := uint64(nowFunc().UnixMilli())
+	b := newUlid(ms)
+
+	mu.Lock()
+	if ms <= v7Millis(lastUlid[:]) {
+		// same millisecond (or a clock step back): continue from the last
+		b = lastUlid
+		incrementUlid(b[:])
+	}
+	lastUlid = b
+	mu.Unlock()
+	return encodeCrockford(b[:])
+}
+
+// UlidTime returns a ULID for an explicit time, for backfills. Its random
+// bits are always freshly drawn, so ULIDs for the same millisecond are not
+// ordered among themselves. Times outside the 48-bit range are clamped.
+//
+// Example: UlidTime(time.UnixMilli(1688096058518)) => 01H455VB4P... (length: 26)
+//
+// Parameters:
+// - t: the time to embed, truncated to the millisecond
+//
+// Returns:
+// - The ULID
+func UlidTime(t time.Time) string {
+	ms := min(max(t.UnixMilli(), 0), 1<<48-1)
+	b := newUlid(uint64(ms))
+	return encodeCrockford(b[:])
+}
+
+// ParseUlid decodes a ULID and returns its embedded time. Decoding is
+// case-insensitive.
+//
+// Example: ParseUlid("01H455VB4PEX5VSKNK084SN02Q") => 2023-06-30T03:34:18.518Z
+//
+// Parameters:
+// - s: a 26-character ULID
+//
+// Returns:
+// - The time (UTC, millisecond resolution), or an error if s is not a
+// valid ULID
+func ParseUlid(s string) (time.Time, error) {
+	b, err := decodeCrockford(s)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid ULID %q: %w", s, err)
+	}
+	return time.UnixMilli(int64(v7Millis(b))).UTC(), nil
+}
+
+// newUlid returns the 16 bytes of a ULID with the given timestamp and
+// fresh random bits.
+func newUlid(ms uint64) [16]byte {
+	var b [16]byte
+	putMillis(b[:], ms)
+	if err := readRandom(b[6:]); err != nil {
+		// fallback
+		binary.BigEndian.PutUint64(b[8:], uint64(time.Now().UnixNano()))
+	}
+	return b
+}
+
+// incrementUlid adds one to the 80 random bits of a ULID, carrying into the
+// timestamp if they overflow.
+func incrementUlid(b []byte) {
+	for i := 15; i >= 0; i-- {
+		b[i]++
+		if b[i] != 0 {
+			return
+		}
+	}
+}
+
+// UuidToUlid converts a UUID into the 26-character ULID form of the same
+// 128-bit value. A UUID and a ULID are two encodings of 16 bytes: hex with
+// hyphens versus Crockford Base32. No string is valid as both, but every
+// value converts losslessly, so systems storing one can consume the other.
+//
+// The bytes are preserved as is: a v7 UUID maps to a ULID carrying the same
+// millisecond timestamp, while other versions give ULIDs whose "time" is
+// meaningless.
+//
+// Example: 01890a5d-ac96-774b-bcce-b302099a8057 => 01H455VB4PEX5VSKNK084SN02Q
+//
+// Parameters:
+// - s: a UUID in any form accepted by ParseWithFormat
+//
+// Returns:
+// - The ULID, or an error if s is invalid
+func UuidToUlid(s string) (string, error) {
+	return ToBase32(s)
+}
+
+// UlidToUuid converts a ULID into the canonical hyphenated UUID of the same
+// 128-bit value, the inverse of UuidToUlid. Decoding is case-insensitive.
+//
+// Example: 01H455VB4PEX5VSKNK084SN02Q => 01890a5d-ac96-774b-bcce-b302099a8057
+//
+// Parameters:
+// - s: a 26-character ULID
+//
+// Returns:
+// - The canonical UUID, or an error if s is not a valid ULID
+func UlidToUuid(s string) (string, error) {
+	return ParseBase32(s)
+}
@@ -0,0 +1,109 @@
+package uid
+
+import (
+	"testing"
+	"time"
+)
+
+func TestUuidToUlid(t *testing.T) {
+	const id = "01890a5d-ac96-774b-bcce-b302099a8057"
+	const ulid = "01H455VB4PEX5VSKNK084SN02Q"
+	got, err := UuidToUlid(id)
+	if err != nil {
+		t.Fatalf("UuidToUlid error: %v", err)
+	}
+	if got != ulid {
+		t.Fatalf("UuidToUlid = %q, want %q", got, ulid)
+	}
+	back, err := UlidToUuid(ulid)
+	if err != nil {
+		t.Fatalf("UlidToUuid error: %v", err)
+	}
+	if back != id {
+		t.Fatalf("UlidToUuid = %q, want %q", back, id)
+	}
+}
+
+func TestUuidUlid_RoundTrip(t *testing.T) {
+	for i := 0; i < 100; i++ {
+		id := UuidV7(true)
+		ulid, err := UuidToUlid(id)
+		if err != nil {
+			t.Fatalf("UuidToUlid(%q) error: %v", id, err)
+		}
+		back, err := UlidToUuid(ulid)
+		if err != nil {
+			t.Fatalf("UlidToUuid(%q) error: %v", ulid, err)
+		}
+		if back != id {
+			t.Fatalf("round trip: got %q want %q", back, id)
+		}
+	}
+}
+
+func TestUuidUlid_Invalid(t *testing.T) {
+	if _, err := UuidToUlid("01H455VB4PEX5VSKNK084SN02Q"); err == nil {
+		t.Fatal("UuidToUlid accepted a ULID")
+	}
+	for _, s := range []string{"", "01890a5d-ac96-774b-bcce-b302099a8057", "8ZZZZZZZZZZZZZZZZZZZZZZZZZ"} {
+		if _, err := UlidToUuid(s); err == nil {
+			t.Fatalf("UlidToUuid(%q) expected error", s)
+		}
+	}
+}
+
+func TestUlid(t *testing.T) {
+	prev := ""
+	for i := 0; i < 10000; i++ {
+		id := Ulid()
+		if len(id) != 26 {
+			t.Fatalf("Ulid() = %q, length %d", id, len(id))
+		}
+		if id <= prev {
+			t.Fatalf("Ulid() not strictly increasing: %q after %q", id, prev)
+		}
+		prev = id
+	}
+}
+
+func TestUlid_MonotonicWithinMillisecond(t *testing.T) {
+	useSteppingClock(t, 0)
+	a, b := Ulid(), Ulid()
+	if b <= a {
+		t.Fatalf("Ulid() not increasing within one millisecond: %q then %q", a, b)
+	}
+	ta, _ := ParseUlid(a)
+	tb, _ := ParseUlid(b)
+	if !ta.Equal(tb) {
+		t.Fatalf("times differ: %v and %v", ta, tb)
+	}
+}
+
+func TestUlidTime(t *testing.T) {
+	want := time.UnixMilli(1688096058518).UTC()
+	id := UlidTime(want.Add(400 * time.Microsecond))
+	if len(id) != 26 || id[:10] != "01H455VB4P" {
+		t.Fatalf("UlidTime = %q, want the 01H455VB4P time prefix", id)
+	}
+	got, err := ParseUlid(id)
+	if err != nil {
+		t.Fatalf("ParseUlid(%q) error: %v", id, err)
+	}
+	if !got.Equal(want) {
+		t.Fatalf("ParseUlid = %v, want %v", got, want)
+	}
+
+	earlier := UlidTime(want.Add(-time.Millisecond))
+	later := UlidTime(want.Add(time.Millisecond))
+	if !(earlier < id && id < later) {
+		t.Fatalf("UlidTime not sortable: %q, %q, %q", earlier, id, later)
+	}
+}
+
+func TestParseUlid_Invalid(t *testing.T) {
+	for _, s := range []string{"", "01H455VB4PEX5VSKNK084SN02", "01H455VB4PEX5VSKNK084SN02U", "8ZZZZZZZZZZZZZZZZZZZZZZZZZ"} {
+		if _, err := ParseUlid(s); err == nil {
+			t.Fatalf("ParseUlid(%q) expected error", s)
+		}
+	}
+}
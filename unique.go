@@ -0,0 +1,118 @@
+package uid
+
+import (
+	"fmt"
+	"sync"
+)
+
+// uniqueRetries is how often UniqueGen.Next regenerates after a collision
+// before giving up.
+const uniqueRetries = 3
+
+// UniqueGen generates IDs guaranteed distinct within its lifetime by
+// remembering every ID it returned. Create one with NewUniqueGenerator for
+// UUIDs or NewUniqueUidGenerator for time-prefixed IDs. It is safe for
+// concurrent use.
+//
+// Memory grows without bound: about 70 bytes per UUID returned (its 16
+// bytes as a string key plus map overhead) and about 80 per time-prefixed
+// ID, so a million IDs cost 70 to 80 MB. Use it in test harnesses and
+// bounded jobs, not in long-running services.
+type UniqueGen struct {
+	mu       sync.Mutex
+	name     string        // "UUID v7" or "nano Uid", for errors
+	generate func() []byte // nil when unsupported
+	format   func(b []byte, withHyphens bool) string
+	err      error // returned by Next when generate is nil
+	seen     map[string]struct{}
+}
+
+// NewUniqueGenerator returns a UniqueGen for the given UUID version.
+//
+// Example: gen := NewUniqueGenerator(7); id, err := gen.Next()
+//
+// Parameters:
+// - version: 1, 4, 6 or 7, the versions that need no caller input; for
+// any other version Next returns an error
+//
+// Returns:
+// - The generator
+func NewUniqueGenerator(version int) *UniqueGen {
+	return &UniqueGen{
+		name:     fmt.Sprintf("UUID v%d", version),
+		generate: generatorFor(version),
+		format:   bytesToUUIDString,
+		err:      fmt.Errorf("no standalone generator for UUID version %d", version),
+		seen:     map[string]struct{}{},
+	}
+}
+
+// NewUniqueUidGenerator returns a UniqueGen for a time-prefixed ID kind.
+// This is where the seen-set matters most: truncation leaves NanoUid two
+// random digits and MicroUid and SecUid none, so under UidStrategyRandom
+// duplicates are likely within one tick of the clock. IDs are generated
+// under the package strategy, see SetUidStrategy.
+//
+// Example: gen := NewUniqueUidGenerator("nano"); id, err := gen.Next()
+//
+// Parameters:
+// - kind: "human", "nano", "micro" or "sec"; for any other kind Next
+// returns an error
+//
+// Returns:
+// - The generator
+func NewUniqueUidGenerator(kind string) *UniqueGen {
+	g := &UniqueGen{
+		name: kind + " Uid",
+		err:  fmt.Errorf("unknown Uid kind %q", kind),
+		seen: map[string]struct{}{},
+	}
+	if k, ok := uidKinds[kind]; ok {
+		g.generate = func() []byte { return []byte(newUid(k.length, k.pause)) }
+		g.format = func(b []byte, withHyphens bool) string {
+			if withHyphens {
+				return formatWithHyphens(string(b), k.groups)
+			}
+			return string(b)
+		}
+	}
+	return g
+}
+
+// Next returns an ID this generator has not returned before. On a
+// collision (astronomically rare for v4 and v7, possible for v1/v6 when
+// the clock sequence wraps and for time-prefixed IDs under
+// UidStrategyRandom) it regenerates, up to 3 times.
+//
+// Parameters:
+// - formatted: when true, include hyphens
+//
+// Returns:
+// - The ID, or an error for an unsupported version or kind or when every
+// retry collided
+func (g *UniqueGen) Next(formatted ...bool) (string, error) {
+	if g.generate == nil {
+		return "", g.err
+	}
+	withHyphens := len(formatted) > 0 && formatted[0]
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	for i := 0; i <= uniqueRetries; i++ {
+		b := g.generate()
+		key := string(b)
+		if _, dup := g.seen[key]; dup {
+			continue
+		}
+		g.seen[key] = struct{}{}
+		return g.format(b, withHyphens), nil
+	}
+	return "", fmt.Errorf("%s collided %d times in a row", g.name, uniqueRetries+1)
+}
+
+// Len returns the number of IDs the generator has returned, which is also
+// the number it keeps in memory.
+func (g *UniqueGen) Len() int {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return len(g.seen)
+}
@@ -0,0 +1,114 @@
+package uid
+
+import (
+	"testing"
+	"time"
+)
+
+func TestUniqueGen(t *testing.T) {
+	for _, version := range []int{1, 4, 6, 7} {
+		gen := NewUniqueGenerator(version)
+		const count = 20000
+		ids := map[string]bool{}
+		for i := 0; i < count; i++ {
+			id, err := gen.Next(i%2 == 0)
+			if err != nil {
+				t.Fatalf("v%d Next error: %v", version, err)
+			}
+			canonical, _ := Normalize(id)
+			ids[canonical] = true
+		}
+		if len(ids) != count || gen.Len() != count {
+			t.Fatalf("v%d: %d unique IDs, Len %d, want %d", version, len(ids), gen.Len(), count)
+		}
+	}
+}
+
+func TestUniqueGen_Collision(t *testing.T) {
+	useFixedReader(t, sequentialBytes) // every v4 is the same
+	gen := NewUniqueGenerator(4)
+	first, err := gen.Next(true)
+	if err != nil {
+		t.Fatalf("Next error: %v", err)
+	}
+	if first != "00010203-0405-4607-8809-0a0b0c0d0e0f" {
+		t.Fatalf("Next = %q", first)
+	}
+	if id, err := gen.Next(); err == nil {
+		t.Fatalf("Next = %q, want a collision error", id)
+	}
+	if gen.Len() != 1 {
+		t.Fatalf("Len = %d, want 1", gen.Len())
+	}
+}
+
+func TestUniqueGen_Unsupported(t *testing.T) {
+	if _, err := NewUniqueGenerator(5).Next(); err == nil {
+		t.Fatal("Next expected error for version 5")
+	}
+	if _, err := NewUniqueUidGenerator("pico").Next(); err == nil {
+		t.Fatal("Next expected error for an unknown Uid kind")
+	}
+}
+
+func TestUniqueUidGen(t *testing.T) {
+	// without the monotonic guard only the seen-set keeps IDs distinct
+	if err := SetUidStrategy(UidStrategyRandom); err != nil {
+		t.Fatalf("SetUidStrategy error: %v", err)
+	}
+	t.Cleanup(func() { SetUidStrategy(UidStrategySleep) })
+	// 10 IDs per 100-ns tick share the timestamp and differ only in their
+	// 2 random digits, so collisions happen and must be retried
+	calls := 0
+	start := time.Date(2025, 8, 31, 15, 11, 33, 0, time.UTC)
+	SetClock(func() time.Time {
+		calls++
+		return start.Add(time.Duration(calls/10) * 100 * time.Nanosecond)
+	})
+	t.Cleanup(func() { SetClock(nil) })
+
+	gen := NewUniqueUidGenerator("nano")
+	const count = 50000
+	ids := map[string]bool{}
+	for i := 0; i < count; i++ {
+		id, err := gen.Next()
+		if err != nil {
+			t.Fatalf("Next error at %d: %v", i, err)
+		}
+		if len(id) != nanoUidLength || !IsNumericUid(id) {
+			t.Fatalf("Next = %q, want a %d-digit ID", id, nanoUidLength)
+		}
+		ids[id] = true
+	}
+	if len(ids) != count || gen.Len() != count {
+		t.Fatalf("%d unique IDs, Len %d, want %d", len(ids), gen.Len(), count)
+	}
+
+	formatted, err := gen.Next(true)
+	if err != nil {
+		t.Fatalf("Next(true) error: %v", err)
+	}
+	assertHyphenPositions(t, formatted, nanoUidLength+3, []int{8, 15, 22})
+}
+
+func TestUniqueUidGen_Collision(t *testing.T) {
+	if err := SetUidStrategy(UidStrategyRandom); err != nil {
+		t.Fatalf("SetUidStrategy error: %v", err)
+	}
+	t.Cleanup(func() { SetUidStrategy(UidStrategySleep) })
+	frozen := time.Date(2025, 8, 31, 15, 11, 33, 0, time.UTC)
+	SetClock(func() time.Time { return frozen })
+	t.Cleanup(func() { SetClock(nil) })
+
+	// a frozen SecUid without the guard repeats on every call
+	gen := NewUniqueUidGenerator("sec")
+	if id, err := gen.Next(); err != nil || id != "20250831151133" {
+		t.Fatalf("Next = %q, %v", id, err)
+	}
+	if id, err := gen.Next(); err == nil {
+		t.Fatalf("Next = %q, want a collision error", id)
+	}
+	if gen.Len() != 1 {
+		t.Fatalf("Len = %d, want 1", gen.Len())
+	}
+}
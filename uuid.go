@@ -2,11 +2,13 @@ package uid
 
 import (
 	"crypto/md5"
-	"crypto/rand"
 	"crypto/sha1"
 	"encoding/binary"
 	"encoding/hex"
 	"errors"
+	"fmt"
+	"hash"
+	"io"
 	"net"
 	"sync"
 	"time"
@@ -53,22 +55,17 @@ func UuidV1(formatted ...bool) string {
 //
 // Parameters:
 // - namespace: a 16-byte UUID (as bytes) used as the namespace
-// - data: the name bytes to hash
+// - data: the name bytes to hash; nil or empty data is allowed and hashes
+// the namespace alone, yielding one fixed UUID per namespace
 // - formatted: when true, include hyphens
 //
 // Returns:
 // - The UUID v3 as a string, or an error
 func UuidV3(namespace string, data []byte, formatted ...bool) (string, error) {
-	ns := []byte(namespace)
-	if len(ns) != 16 {
-		return "", errors.New("namespace must be 16 bytes")
+	sum, err := newHashed(md5.New(), 3, namespace, data)
+	if err != nil {
+		return "", err
 	}
-	h := md5.New()
-	h.Write(ns)
-	h.Write(data)
-	sum := h.Sum(nil)[:16]
-	setVersion(sum, 3)
-	setVariantRFC4122(sum)
 	withHyphens := len(formatted) > 0 && formatted[0]
 	return bytesToUUIDString(sum, withHyphens), nil
 }
@@ -89,6 +86,28 @@ func UuidV4(formatted ...bool) string {
 	return bytesToUUIDString(newV4(), withHyphens)
 }
 
+// UuidV4Err is like UuidV4 but returns the error of the random source
+// instead of silently falling back to clock-derived bytes, which would
+// yield a guessable ID. Use it for security-sensitive IDs such as tokens.
+//
+// Example (no hyphens): 550e8400e29b41d4a716446655440000 (length: 32)
+//
+// Parameters:
+// - formatted: when true, include hyphens
+//
+// Returns:
+// - A random UUID (version 4), or the error of the random source
+func UuidV4Err(formatted ...bool) (string, error) {
+	b := make([]byte, 16)
+	if err := readRandom(b); err != nil {
+		return "", fmt.Errorf("reading randomness: %w", err)
+	}
+	setVersion(b, 4)
+	setVariantRFC4122(b)
+	withHyphens := len(formatted) > 0 && formatted[0]
+	return bytesToUUIDString(b, withHyphens), nil
+}
+
 // UuidV5 returns a version 5 (SHA-1 name-based) UUID.
 // Provide a 16-byte namespace UUID and arbitrary data.
 //
@@ -98,22 +117,17 @@ func UuidV4(formatted ...bool) string {
 //
 // Parameters:
 // - namespace: a 16-byte UUID (as bytes) used as the namespace
-// - data: the name bytes to hash
+// - data: the name bytes to hash; nil or empty data is allowed and hashes
+// the namespace alone, yielding one fixed UUID per namespace
 // - formatted: when true, include hyphens
 //
 // Returns:
 // - The UUID v5 as a string, or an error
 func UuidV5(namespace string, data []byte, formatted ...bool) (string, error) {
-	ns := []byte(namespace)
-	if len(ns) != 16 {
-		return "", errors.New("namespace must be 16 bytes")
+	sum, err := newHashed(sha1.New(), 5, namespace, data)
+	if err != nil {
+		return "", err
 	}
-	h := sha1.New()
-	h.Write(ns)
-	h.Write(data)
-	sum := h.Sum(nil)[:16]
-	setVersion(sum, 5)
-	setVariantRFC4122(sum)
 	withHyphens := len(formatted) > 0 && formatted[0]
 	return bytesToUUIDString(sum, withHyphens), nil
 }
@@ -143,6 +157,10 @@ func UuidV6(formatted ...bool) string {
 //
 // Draft: https://en.wikipedia.org/wiki/Universally_unique_identifier#Version_7_(timestamp_and_random)
 //
+// UUIDs are strictly increasing process-wide: within one millisecond the
+// random bits of the previous UUID are incremented (RFC 9562 monotonic
+// random method) instead of drawn again.
+//
 // Parameters:
 // - formatted: when true, include hyphens
 //
@@ -153,6 +171,29 @@ func UuidV7(formatted ...bool) string {
 	return bytesToUUIDString(newV7(), withHyphens)
 }
 
+// UuidV7At returns a version 7 UUID embedding the time t instead of the
+// current time, for importing historical records with their original
+// event time. Unlike UuidV7 the random bits are always drawn fresh, so
+// UUIDs for the same millisecond are not ordered among themselves.
+//
+// Example: UuidV7At(time.UnixMilli(1688096058518), true) => 01890a5d-ac96-7... (length: 36)
+//
+// Parameters:
+// - t: the time to embed, truncated to the millisecond; between the Unix
+// epoch and year 10889 (2^48 milliseconds)
+// - formatted: when true, include hyphens
+//
+// Returns:
+// - The UUID v7 as a string, or an error if t is out of range
+func UuidV7At(t time.Time, formatted ...bool) (string, error) {
+	ms := t.UnixMilli()
+	if ms < 0 || ms >= 1<<48 {
+		return "", errors.New("time must be between the Unix epoch and 2^48 milliseconds")
+	}
+	withHyphens := len(formatted) > 0 && formatted[0]
+	return bytesToUUIDString(newV7At(uint64(ms)), withHyphens), nil
+}
+
 // ---- Internal implementation ----
 
 var (
@@ -160,24 +201,25 @@ var (
 	nodeIDData [6]byte
 	clockSeq   uint16 // 14-bit
 	mu         sync.Mutex
-	lastTime   uint64 // 100-ns intervals since 1582
+	lastTime   uint64   // 100-ns intervals since 1582
+	lastV7     [16]byte // last version 7 UUID handed out, see newV7
 )
 
 const gregorianToUnix100ns = uint64(122192928000000000)
 
 func initState() {
-	// Initialize node ID
-	if nid, ok := systemNodeID(); ok {
+	// Initialize node ID; the hardware address is skipped in privacy mode
+	if nid, ok := systemNodeIDAllowed(); ok {
 		copy(nodeIDData[:], nid)
 	} else {
 		// Random multicast node per RFC 4122
-		if _, err := rand.Read(nodeIDData[:]); err == nil {
+		if err := readRandom(nodeIDData[:]); err == nil {
 			nodeIDData[0] |= 0x01 // multicast bit
 		}
 	}
 	// Initialize clock sequence randomly (14-bit)
 	var b [2]byte
-	if _, err := rand.Read(b[:]); err == nil {
+	if err := readRandom(b[:]); err == nil {
 		clockSeq = binary.BigEndian.Uint16(b[:]) & 0x3FFF
 	} else {
 		clockSeq = uint16(time.Now().UnixNano()) & 0x3FFF
@@ -201,7 +243,7 @@ func systemNodeID() ([]byte, bool) {
 }
 
 func now100ns() uint64 {
-	ns := uint64(time.Now().UnixNano())
+	ns := uint64(nowFunc().UnixNano())
 	return ns/100 + gregorianToUnix100ns
 }
 
@@ -215,9 +257,34 @@ func setVersion(b []byte, ver int) {
 	b[6] |= byte(ver<<4) & 0xF0
 }
 
+// newHashed returns the name-based UUID of the given version: the first 16
+// bytes of h over the namespace and data.
+func newHashed(h hash.Hash, version int, namespace string, data []byte) ([]byte, error) {
+	return newHashedFunc(h, version, namespace, func(w io.Writer) error {
+		_, err := w.Write(data)
+		return err
+	})
+}
+
+// newHashedFunc is newHashed for input that is streamed or encoded: write
+// feeds h after the namespace, and its error is returned.
+func newHashedFunc(h hash.Hash, version int, namespace string, write func(w io.Writer) error) ([]byte, error) {
+	if len(namespace) != 16 {
+		return nil, errors.New("namespace must be 16 bytes")
+	}
+	h.Write([]byte(namespace))
+	if err := write(h); err != nil {
+		return nil, err
+	}
+	sum := h.Sum(nil)[:16]
+	setVersion(sum, version)
+	setVariantRFC4122(sum)
+	return sum, nil
+}
+
 func newV4() []byte {
 	b := make([]byte, 16)
-	if _, err := rand.Read(b); err != nil {
+	if err := readRandom(b); err != nil {
 		// fallback: timestamp-based randomness
 		binary.BigEndian.PutUint64(b[0:8], uint64(time.Now().UnixNano()))
 		binary.BigEndian.PutUint64(b[8:16], uint64(time.Now().UnixNano()))
@@ -227,19 +294,44 @@ func newV4() []byte {
 	return b
 }
 
-func newV1() []byte {
+// nextClock returns the current Gregorian timestamp, the clock sequence to
+// use with it and the node ID, bumping the sequence when the clock has not
+// advanced.
+func nextClock() (uint64, uint16, [6]byte) {
 	onceInit.Do(initState)
-	b := make([]byte, 16)
-
 	mu.Lock()
+	defer mu.Unlock()
 	t := now100ns()
 	if t <= lastTime {
 		clockSeq = (clockSeq + 1) & 0x3FFF
+		if clockSeq == 0 {
+			clockSeqWraps.Add(1)
+		}
 	}
 	lastTime = t
-	cs := clockSeq
-	mu.Unlock()
+	return t, clockSeq, nodeIDData
+}
 
+// currentNodeID returns a copy of the package node ID.
+func currentNodeID() [6]byte {
+	onceInit.Do(initState)
+	mu.Lock()
+	defer mu.Unlock()
+	return nodeIDData
+}
+
+func newV1() []byte {
+	b := make([]byte, 16)
+
+	t, cs, node := nextClock()
+
+	putV1(b, t, cs, node[:])
+	return b
+}
+
+// putV1 writes a version 1 layout into b from a 60-bit Gregorian timestamp,
+// a 14-bit clock sequence and a 6-byte node.
+func putV1(b []byte, t uint64, cs uint16, node []byte) {
 	// time fields per RFC 4122
 	tl := uint32(t & 0xFFFFFFFF)
 	tm := uint16((t >> 32) & 0xFFFF)
@@ -254,25 +346,27 @@ func newV1() []byte {
 	b[8] = byte((cs>>8)&0x3F) | 0x80 // variant 10
 	b[9] = byte(cs)
 
-	copy(b[10:], nodeIDData[:])
-	return b
+	copy(b[10:], node)
 }
 
 func newV6() []byte {
-	onceInit.Do(initState)
 	b := make([]byte, 16)
 
-	mu.Lock()
-	t := now100ns()
-	if t <= lastTime {
-		clockSeq = (clockSeq + 1) & 0x3FFF
-	}
-	lastTime = t
-	cs := clockSeq
-	mu.Unlock()
+	t, cs, node := nextClock()
+
+	putV6(b, t, cs, node[:])
+	return b
+}
+
+// putV6 writes a version 6 layout into b from a 60-bit Gregorian timestamp,
+// a 14-bit clock sequence and a 6-byte node.
+func putV6(b []byte, t uint64, cs uint16, node []byte) {
+	// RFC 9562 splits the 60-bit timestamp 32/16/12 from its most
+	// significant bit; anything above bit 59 is not part of it
+	t &= 1<<60 - 1
 
 	// Reorder v1 timestamp into v6 (time-ordered) layout
-	th := uint32(t >> 28)            // top 32 bits
+	th := uint32(t >> 28)            // top 32 of 60 bits
 	tm := uint16((t >> 12) & 0xFFFF) // next 16 bits
 	tl := uint16(t & 0x0FFF)         // low 12 bits
 	tl |= 0x6000                     // set version 6
@@ -285,24 +379,38 @@ func newV6() []byte {
 	b[8] = byte((cs>>8)&0x3F) | 0x80 // variant 10
 	b[9] = byte(cs)
 
-	copy(b[10:], nodeIDData[:])
-	return b
+	copy(b[10:], node)
 }
 
+// newV7 returns a version 7 UUID following the monotonic random method of
+// RFC 9562 (section 6.2, method 2): while the millisecond has not advanced
+// past the previous UUID's, the previous 74 random bits are incremented
+// instead of drawn again, so UUIDs are strictly increasing process-wide.
 func newV7() []byte {
+	ms := uint64(nowFunc().UnixMilli())
+	b := newV7At(ms)
+
+	mu.Lock()
+	if ms <= v7Millis(lastV7[:]) {
+		// same millisecond (or a clock step back): continue from the last
+		copy(b, lastV7[:])
+		incrementV7(b)
+	}
+	copy(lastV7[:], b)
+	mu.Unlock()
+	return b
+}
+
+// newV7At returns a version 7 UUID for the Unix millisecond ms with fresh
+// random bits.
+func newV7At(ms uint64) []byte {
 	b := make([]byte, 16)
 	// 48-bit Unix ms timestamp
-	ts := uint64(time.Now().UnixMilli())
-	b[0] = byte(ts >> 40)
-	b[1] = byte(ts >> 32)
-	b[2] = byte(ts >> 24)
-	b[3] = byte(ts >> 16)
-	b[4] = byte(ts >> 8)
-	b[5] = byte(ts)
+	putMillis(b, ms)
 
 	// 12 bits random (A), 62 bits random (B)
 	var r [10]byte
-	if _, err := rand.Read(r[:]); err != nil {
+	if err := readRandom(r[:]); err != nil {
 		// fallback
 		binary.BigEndian.PutUint64(r[2:], uint64(time.Now().UnixNano()))
 	}
@@ -317,15 +425,41 @@ func newV7() []byte {
 	return b
 }
 
+// putMillis writes the low 48 bits of ms big-endian into b[0:6].
+func putMillis(b []byte, ms uint64) {
+	b[0] = byte(ms >> 40)
+	b[1] = byte(ms >> 32)
+	b[2] = byte(ms >> 24)
+	b[3] = byte(ms >> 16)
+	b[4] = byte(ms >> 8)
+	b[5] = byte(ms)
+}
+
 func bytesToUUIDString(b []byte, withHyphens bool) string {
+	return encodeUUID(b, withHyphens, upperCase.Load())
+}
+
+// canonicalString returns the canonical form of b: lowercase and
+// hyphenated, regardless of SetDefaultCase.
+func canonicalString(b []byte) string {
+	return encodeUUID(b, true, false)
+}
+
+func encodeUUID(b []byte, withHyphens bool, upper bool) string {
 	if !withHyphens {
 		dst := make([]byte, hex.EncodedLen(len(b)))
 		hex.Encode(dst, b)
+		if upper {
+			upperHex(dst)
+		}
 		return string(dst)
 	}
 	// 8-4-4-4-12
 	hexstr := make([]byte, hex.EncodedLen(len(b)))
 	hex.Encode(hexstr, b)
+	if upper {
+		upperHex(hexstr)
+	}
 	// insert hyphens
 	out := make([]byte, 36)
 	copy(out[0:8], hexstr[0:8])
@@ -339,3 +473,35 @@ func bytesToUUIDString(b []byte, withHyphens bool) string {
 	copy(out[24:36], hexstr[20:32])
 	return string(out)
 }
+
+// incrementV7 treats the 74 random bits of a version 7 UUID as a counter and
+// adds one, carrying into the timestamp if the random bits overflow. The
+// version and variant bits are preserved.
+func incrementV7(b []byte) {
+	for i := 15; i >= 9; i-- {
+		b[i]++
+		if b[i] != 0 {
+			return
+		}
+	}
+	if b[8]&0x3F != 0x3F {
+		b[8]++
+		return
+	}
+	b[8] &= 0xC0
+	b[7]++
+	if b[7] != 0 {
+		return
+	}
+	if b[6]&0x0F != 0x0F {
+		b[6]++
+		return
+	}
+	b[6] &= 0xF0
+	for i := 5; i >= 0; i-- {
+		b[i]++
+		if b[i] != 0 {
+			return
+		}
+	}
+}
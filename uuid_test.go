@@ -1,6 +1,9 @@
 package uid
 
-import "testing"
+import (
+    "testing"
+    "time"
+)
 
 // helper to assert UUID length and version nibble
 func assertLenAndVersion(t *testing.T, s string, wantLen int, wantVersion byte, withHyphens bool) {
@@ -197,3 +200,95 @@ func TestUuidFormatted(t *testing.T) {
         t.Fatal("Uuid 1 and Timestamp 2 must not be the same")
     }
 }
+
+func TestUuidV7_StrictlyIncreasing(t *testing.T) {
+    prev := UuidV7()
+    for i := 0; i < 10000; i++ {
+        next := UuidV7()
+        if next <= prev {
+            t.Fatalf("UuidV7 not strictly increasing at %d: %s <= %s", i, next, prev)
+        }
+        prev = next
+    }
+}
+
+func TestUuidV7_MonotonicWithinMillisecond(t *testing.T) {
+    useSteppingClock(t, 0) // frozen clock: every UUID falls in the same millisecond
+    prev := UuidV7(true)
+    for i := 0; i < 1000; i++ {
+        next := UuidV7(true)
+        if next <= prev {
+            t.Fatalf("UuidV7 not strictly increasing at %d: %s <= %s", i, next, prev)
+        }
+        assertLenAndVersion(t, next, 36, '7', true)
+        prev = next
+    }
+}
+
+func TestUuidV7At(t *testing.T) {
+    want := time.UnixMilli(1688096058518).UTC()
+    s, err := UuidV7At(want.Add(300*time.Microsecond), true)
+    if err != nil {
+        t.Fatalf("UuidV7At error: %v", err)
+    }
+    assertLenAndVersion(t, s, 36, '7', true)
+    if s[:15] != "01890a5d-ac96-7" {
+        t.Fatalf("UuidV7At = %q, want the 01890a5d-ac96 time prefix", s)
+    }
+    got, err := ExtractTimestamp(s)
+    if err != nil {
+        t.Fatalf("ExtractTimestamp(%q) error: %v", s, err)
+    }
+    if !got.Equal(want) {
+        t.Fatalf("ExtractTimestamp = %v, want %v", got, want)
+    }
+
+    bare, _ := UuidV7At(want)
+    assertLenAndVersion(t, bare, 32, '7', false)
+
+    for _, bad := range []time.Time{time.UnixMilli(-1), time.UnixMilli(1 << 48)} {
+        if _, err := UuidV7At(bad); err == nil {
+            t.Fatalf("UuidV7At(%v) expected error", bad)
+        }
+    }
+}
+
+// RFC 9562, appendix A: 2022-02-22 14:22:22 -05:00, clock sequence 0x33C8,
+// node 9F6BDECED846.
+func TestPutV1V6_RFC9562Vectors(t *testing.T) {
+    ts, err := gregorian100ns(time.Date(2022, 2, 22, 19, 22, 22, 0, time.UTC))
+    if err != nil {
+        t.Fatalf("gregorian100ns error: %v", err)
+    }
+    node := []byte{0x9f, 0x6b, 0xde, 0xce, 0xd8, 0x46}
+
+    b := make([]byte, 16)
+    putV1(b, ts, 0x33c8, node)
+    if got, want := canonicalString(b), "c232ab00-9414-11ec-b3c8-9f6bdeced846"; got != want {
+        t.Fatalf("v1 = %s, want %s", got, want)
+    }
+    putV6(b, ts, 0x33c8, node)
+    if got, want := canonicalString(b), "1ec9414c-232a-6b00-b3c8-9f6bdeced846"; got != want {
+        t.Fatalf("v6 = %s, want %s", got, want)
+    }
+
+    // bits above the 60-bit timestamp are masked off
+    putV6(b, ts|0xF<<60, 0x33c8, node)
+    if got, want := canonicalString(b), "1ec9414c-232a-6b00-b3c8-9f6bdeced846"; got != want {
+        t.Fatalf("v6 with high bits = %s, want %s", got, want)
+    }
+}
+
+func TestUuidV6_TimestampMatchesNow(t *testing.T) {
+    before := time.Now()
+    s := UuidV6(true)
+    after := time.Now()
+    got, err := ExtractTimestamp(s)
+    if err != nil {
+        t.Fatalf("ExtractTimestamp(%q) error: %v", s, err)
+    }
+    // the embedded time is truncated to 100 ns
+    if got.Before(before.Truncate(100*time.Nanosecond)) || got.After(after) {
+        t.Fatalf("ExtractTimestamp(%q) = %v, want between %v and %v", s, got, before, after)
+    }
+}
@@ -0,0 +1,111 @@
+package uid
+
+import (
+	"crypto/md5"
+	"crypto/sha1"
+)
+
+// UUID is a 16-byte UUID value. Unlike the string-returning functions it
+// is parsed once, compares with == and can be used as a map key. The zero
+// value is the nil UUID.
+type UUID [16]byte
+
+// NewV1 returns a version 1 (time-based) UUID, see UuidV1.
+func NewV1() UUID {
+	return UUID(newV1())
+}
+
+// NewV3 returns a version 3 (MD5 name-based) UUID, see UuidV3.
+//
+// Parameters:
+// - namespace: a 16-byte UUID (as bytes) used as the namespace
+// - data: the name bytes to hash
+//
+// Returns:
+// - The UUID, or an error if the namespace is not 16 bytes
+func NewV3(namespace string, data []byte) (UUID, error) {
+	b, err := newHashed(md5.New(), 3, namespace, data)
+	if err != nil {
+		return UUID{}, err
+	}
+	return UUID(b), nil
+}
+
+// NewV4 returns a random (version 4) UUID, see UuidV4.
+func NewV4() UUID {
+	return UUID(newV4())
+}
+
+// NewV5 returns a version 5 (SHA-1 name-based) UUID, see UuidV5.
+//
+// Parameters:
+// - namespace: a 16-byte UUID (as bytes) used as the namespace
+// - data: the name bytes to hash
+//
+// Returns:
+// - The UUID, or an error if the namespace is not 16 bytes
+func NewV5(namespace string, data []byte) (UUID, error) {
+	b, err := newHashed(sha1.New(), 5, namespace, data)
+	if err != nil {
+		return UUID{}, err
+	}
+	return UUID(b), nil
+}
+
+// NewV6 returns a version 6 (time-ordered) UUID, see UuidV6.
+func NewV6() UUID {
+	return UUID(newV6())
+}
+
+// NewV7 returns a version 7 (Unix time-based) UUID, see UuidV7.
+func NewV7() UUID {
+	return UUID(newV7())
+}
+
+// ParseUUID parses s into a UUID.
+//
+// Example: ParseUUID("550e8400-e29b-41d4-a716-446655440000")
+//
+// Parameters:
+// - s: a UUID in any form accepted by Parse
+//
+// Returns:
+// - The UUID, or an error if s is invalid
+func ParseUUID(s string) (UUID, error) {
+	b, err := Parse(s)
+	if err != nil {
+		return UUID{}, err
+	}
+	return UUID(b), nil
+}
+
+// String returns the UUID without hyphens, like the package generators
+// called without arguments.
+//
+// Example: 550e8400e29b41d4a716446655440000 (length: 32)
+func (u UUID) String() string {
+	return bytesToUUIDString(u[:], false)
+}
+
+// StringFormatted returns the UUID with hyphens.
+//
+// Example: 550e8400-e29b-41d4-a716-446655440000 (length: 36)
+func (u UUID) StringFormatted() string {
+	return bytesToUUIDString(u[:], true)
+}
+
+// Version returns the version nibble (0-15) of the UUID.
+func (u UUID) Version() int {
+	return int(u[6] >> 4)
+}
+
+// Variant returns the variant of the UUID: "NCS", "RFC4122", "Microsoft"
+// or "Future".
+func (u UUID) Variant() string {
+	return variantOf(u[:])
+}
+
+// IsZero reports whether u is the nil UUID (all zero bytes).
+func (u UUID) IsZero() bool {
+	return u == UUID{}
+}
@@ -0,0 +1,76 @@
+package uid
+
+import "testing"
+
+func TestUUIDType_Constructors(t *testing.T) {
+	cases := map[int]UUID{1: NewV1(), 4: NewV4(), 6: NewV6(), 7: NewV7()}
+	v3, err := NewV3(NamespaceDNS, []byte("www.example.com"))
+	if err != nil {
+		t.Fatalf("NewV3 error: %v", err)
+	}
+	cases[3] = v3
+	v5, err := NewV5(NamespaceDNS, []byte("www.example.com"))
+	if err != nil {
+		t.Fatalf("NewV5 error: %v", err)
+	}
+	cases[5] = v5
+	for version, u := range cases {
+		if got := u.Version(); got != version {
+			t.Fatalf("Version() = %d, want %d", got, version)
+		}
+		if got := u.Variant(); got != "RFC4122" {
+			t.Fatalf("Variant() = %q, want RFC4122", got)
+		}
+		if u.IsZero() {
+			t.Fatalf("v%d UUID reports IsZero", version)
+		}
+		assertLenAndVersion(t, u.String(), 32, byte('0'+version), false)
+		assertLenAndVersion(t, u.StringFormatted(), 36, byte('0'+version), true)
+	}
+	if got := v5.StringFormatted(); got != "2ed6657d-e927-568b-95e1-2665a8aea6a2" {
+		t.Fatalf("NewV5 = %q", got)
+	}
+	if _, err := NewV3("short", nil); err == nil {
+		t.Fatal("NewV3 expected error for a short namespace")
+	}
+	if _, err := NewV5("short", nil); err == nil {
+		t.Fatal("NewV5 expected error for a short namespace")
+	}
+}
+
+func TestUUIDType_ParseAndCompare(t *testing.T) {
+	a, err := ParseUUID("550e8400-e29b-41d4-a716-446655440000")
+	if err != nil {
+		t.Fatalf("ParseUUID error: %v", err)
+	}
+	b, err := ParseUUID("550E8400E29B41D4A716446655440000")
+	if err != nil {
+		t.Fatalf("ParseUUID error: %v", err)
+	}
+	if a != b {
+		t.Fatalf("equal UUIDs compare unequal: %v, %v", a, b)
+	}
+	set := map[UUID]bool{a: true}
+	if !set[b] {
+		t.Fatal("UUID not usable as a map key")
+	}
+	if got := a.String(); got != "550e8400e29b41d4a716446655440000" {
+		t.Fatalf("String() = %q", got)
+	}
+	if _, err := ParseUUID("nope"); err == nil {
+		t.Fatal("ParseUUID expected error")
+	}
+}
+
+func TestUUIDType_Zero(t *testing.T) {
+	var u UUID
+	if !u.IsZero() {
+		t.Fatal("zero value is not IsZero")
+	}
+	if got := u.StringFormatted(); got != "00000000-0000-0000-0000-000000000000" {
+		t.Fatalf("StringFormatted() = %q", got)
+	}
+	if u.Version() != 0 || u.Variant() != "NCS" {
+		t.Fatalf("zero UUID: version %d, variant %q", u.Version(), u.Variant())
+	}
+}
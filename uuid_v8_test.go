@@ -0,0 +1,117 @@
+package uid
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestUuidV8Linked(t *testing.T) {
+	content := []byte("the quick brown fox")
+	a := UuidV8Linked(content)
+	b := UuidV8Linked(content, true)
+	assertLenAndVersion(t, a, 32, '8', false)
+	assertLenAndVersion(t, b, 36, '8', true)
+	if a == UuidV8Linked(content) {
+		t.Fatal("UuidV8Linked values must differ")
+	}
+
+	for _, id := range []string{a, b} {
+		ok, err := VerifyLinked(id, content)
+		if err != nil {
+			t.Fatalf("VerifyLinked error: %v", err)
+		}
+		if !ok {
+			t.Fatalf("VerifyLinked(%s) = false, want true", id)
+		}
+	}
+}
+
+func TestVerifyLinked_Mismatch(t *testing.T) {
+	id := UuidV8Linked([]byte("original"))
+	ok, err := VerifyLinked(id, []byte("tampered"))
+	if err != nil {
+		t.Fatalf("VerifyLinked error: %v", err)
+	}
+	if ok {
+		t.Fatal("VerifyLinked must be false for different content")
+	}
+}
+
+func TestVerifyLinked_Invalid(t *testing.T) {
+	if _, err := VerifyLinked("not-a-uuid", nil); err == nil {
+		t.Fatal("VerifyLinked expected error for invalid UUID")
+	}
+	if _, err := VerifyLinked(UuidV4(), nil); err == nil {
+		t.Fatal("VerifyLinked expected error for non-v8 UUID")
+	}
+}
+
+func TestGeneratorV8Env(t *testing.T) {
+	var g Generator
+	for _, env := range []uint8{EnvDev, EnvStaging, EnvProd, EnvOther} {
+		for i := 0; i < 20; i++ {
+			id, err := g.V8Env(env)
+			if err != nil {
+				t.Fatalf("V8Env(%d) error: %v", env, err)
+			}
+			assertLenAndVersion(t, id, 32, '8', false)
+			if id[16] < '8' || id[16] > 'b' {
+				t.Fatalf("V8Env(%d) variant nibble = %c; value=%s", env, id[16], id)
+			}
+			got, err := EnvOf(id)
+			if err != nil {
+				t.Fatalf("EnvOf error: %v", err)
+			}
+			if got != env {
+				t.Fatalf("EnvOf(%s) = %d, want %d", id, got, env)
+			}
+		}
+	}
+
+	formatted, err := g.V8Env(EnvProd, true)
+	if err != nil {
+		t.Fatalf("V8Env error: %v", err)
+	}
+	assertLenAndVersion(t, formatted, 36, '8', true)
+
+	if _, err := g.V8Env(4); err == nil {
+		t.Fatal("V8Env expected error for env code 4")
+	}
+}
+
+func TestEnvOf_Invalid(t *testing.T) {
+	if _, err := EnvOf(UuidV4()); err == nil {
+		t.Fatal("EnvOf expected error for non-v8 UUID")
+	}
+	if _, err := EnvOf("invalid"); err == nil {
+		t.Fatal("EnvOf expected error for invalid UUID")
+	}
+}
+
+func TestUuidV8(t *testing.T) {
+	data := []byte{0x55, 0x0e, 0x84, 0x00, 0xe2, 0x9b, 0x41, 0xd4, 0x27, 0x16, 0x44, 0x66, 0x55, 0x44, 0x00, 0x00}
+	orig := append([]byte(nil), data...)
+
+	got, err := UuidV8(data, true)
+	if err != nil {
+		t.Fatalf("UuidV8 error: %v", err)
+	}
+	if want := "550e8400-e29b-81d4-a716-446655440000"; got != want {
+		t.Fatalf("UuidV8 = %q, want %q", got, want)
+	}
+	if bare, _ := UuidV8(data); bare != "550e8400e29b81d4a716446655440000" {
+		t.Fatalf("UuidV8 unformatted = %q", bare)
+	}
+	if !bytes.Equal(data, orig) {
+		t.Fatalf("UuidV8 modified its input: %x", data)
+	}
+	if b, _ := Parse(got); variantOf(b) != "RFC4122" {
+		t.Fatalf("UuidV8 variant = %s", variantOf(b))
+	}
+
+	for _, n := range []int{0, 15, 17} {
+		if _, err := UuidV8(make([]byte, n)); err == nil {
+			t.Fatalf("UuidV8 with %d bytes expected error", n)
+		}
+	}
+}
@@ -0,0 +1,85 @@
+package uid
+
+import "fmt"
+
+// IsValid reports whether s passes Validate.
+//
+// Parameters:
+// - s: the candidate UUID
+//
+// Returns:
+// - true if s is a well-formed RFC 4122 UUID of version 1 to 8
+func IsValid(s string) bool {
+	return Validate(s) == nil
+}
+
+// Validate checks that s is a well-formed UUID as produced by the
+// generators, for use as a guard on untrusted input:
+//   - 32 characters (bare) or 36 characters with hyphens at 8, 13, 18, 23
+//   - hex digits only (either case)
+//   - the RFC 4122 variant (10xx) in the clock-seq byte
+//   - a version nibble between 1 and 8
+//
+// URN and braced forms are not accepted, nor are the nil and max UUIDs,
+// which carry no version. Validate does not allocate on success.
+//
+// Parameters:
+// - s: the candidate UUID
+//
+// Returns:
+// - nil if s is valid, otherwise an error describing the first problem
+func Validate(s string) error {
+	var hyphens bool
+	switch len(s) {
+	case 32:
+	case 36:
+		hyphens = true
+	default:
+		return fmt.Errorf("invalid UUID length %d, want 32 or 36", len(s))
+	}
+
+	// version and variant are the 13th and 17th hex digits
+	var version, variant byte
+	n := 0
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if hyphens && (i == 8 || i == 13 || i == 18 || i == 23) {
+			if c != '-' {
+				return fmt.Errorf("invalid UUID: expected hyphen at index %d", i)
+			}
+			continue
+		}
+		v, ok := hexValue(c)
+		if !ok {
+			return fmt.Errorf("invalid UUID: non-hex character %q at index %d", c, i)
+		}
+		switch n {
+		case 12:
+			version = v
+		case 16:
+			variant = v
+		}
+		n++
+	}
+
+	if variant&0xC != 0x8 {
+		return fmt.Errorf("invalid UUID: %s variant, not RFC 4122", variantOf([]byte{8: variant << 4}))
+	}
+	if version < 1 || version > 8 {
+		return fmt.Errorf("invalid UUID: unsupported version %d", version)
+	}
+	return nil
+}
+
+// hexValue returns the value of the hex digit c.
+func hexValue(c byte) (byte, bool) {
+	switch {
+	case c >= '0' && c <= '9':
+		return c - '0', true
+	case c >= 'a' && c <= 'f':
+		return c - 'a' + 10, true
+	case c >= 'A' && c <= 'F':
+		return c - 'A' + 10, true
+	}
+	return 0, false
+}
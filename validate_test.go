@@ -0,0 +1,53 @@
+package uid
+
+import "testing"
+
+func TestValidate(t *testing.T) {
+	valid := []string{
+		"550e8400-e29b-41d4-a716-446655440000",
+		"550E8400E29B41D4A716446655440000",
+		"6ba7b810-9dad-11d1-80b4-00c04fd430c8",
+		UuidV1(), UuidV4(true), UuidV6(), UuidV7(true), UuidV8Linked([]byte("x")),
+	}
+	for _, s := range valid {
+		if err := Validate(s); err != nil {
+			t.Fatalf("Validate(%q) error: %v", s, err)
+		}
+		if !IsValid(s) {
+			t.Fatalf("IsValid(%q) = false", s)
+		}
+	}
+}
+
+func TestValidate_Invalid(t *testing.T) {
+	invalid := []string{
+		"",
+		"550e8400e29b41d4a71644665544000",
+		"550e8400-e29b-41d4-a716-4466554400001",
+		"550e8400e-29b-41d4-a716-446655440000",
+		"550e8400-e29b-41d4-a716-44665544000g",
+		"550e8400-e29b-41d4-c716-446655440000", // Microsoft variant
+		"550e8400-e29b-41d4-0716-446655440000", // NCS variant
+		"550e8400-e29b-01d4-a716-446655440000", // version 0
+		"550e8400-e29b-91d4-a716-446655440000", // version 9
+		"00000000-0000-0000-0000-000000000000",
+		"ffffffff-ffff-ffff-ffff-ffffffffffff",
+		"{550e8400-e29b-41d4-a716-446655440000}",
+		"urn:uuid:550e8400-e29b-41d4-a716-446655440000",
+	}
+	for _, s := range invalid {
+		if err := Validate(s); err == nil {
+			t.Fatalf("Validate(%q) expected error", s)
+		}
+		if IsValid(s) {
+			t.Fatalf("IsValid(%q) = true", s)
+		}
+	}
+}
+
+func TestValidate_NoAllocs(t *testing.T) {
+	s := UuidV4(true)
+	if allocs := testing.AllocsPerRun(100, func() { _ = Validate(s) }); allocs != 0 {
+		t.Fatalf("Validate allocated %v times per call", allocs)
+	}
+}
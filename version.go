@@ -0,0 +1,55 @@
+package uid
+
+// Version returns the version nibble of a UUID (the 13th hex digit).
+//
+// Example: Version("01890a5d-ac96-774b-bcce-b302099a8057") => 7
+//
+// Parameters:
+// - s: a UUID in any form accepted by ParseWithFormat
+//
+// Returns:
+// - The version (0-15), or an error if s is invalid
+func Version(s string) (int, error) {
+	b, _, err := ParseWithFormat(s)
+	if err != nil {
+		return 0, err
+	}
+	return int(b[6] >> 4), nil
+}
+
+// IsVersion reports whether s is a valid UUID of version v, for guard
+// clauses.
+//
+// Example: IsVersion("01890a5d-ac96-774b-bcce-b302099a8057", 7) => true
+//
+// Parameters:
+// - s: a UUID in any form accepted by ParseWithFormat
+// - v: the expected version
+//
+// Returns:
+// - true if s parses and its version nibble is v; false otherwise
+func IsVersion(s string, v int) bool {
+	version, err := Version(s)
+	return err == nil && version == v
+}
+
+// IsV1 reports whether s is a valid version 1 UUID, see IsVersion.
+func IsV1(s string) bool { return IsVersion(s, 1) }
+
+// IsV3 reports whether s is a valid version 3 UUID, see IsVersion.
+func IsV3(s string) bool { return IsVersion(s, 3) }
+
+// IsV4 reports whether s is a valid version 4 UUID, see IsVersion.
+func IsV4(s string) bool { return IsVersion(s, 4) }
+
+// IsV5 reports whether s is a valid version 5 UUID, see IsVersion.
+func IsV5(s string) bool { return IsVersion(s, 5) }
+
+// IsV6 reports whether s is a valid version 6 UUID, see IsVersion.
+func IsV6(s string) bool { return IsVersion(s, 6) }
+
+// IsV7 reports whether s is a valid version 7 UUID, see IsVersion.
+func IsV7(s string) bool { return IsVersion(s, 7) }
+
+// IsV8 reports whether s is a valid version 8 UUID, see IsVersion.
+func IsV8(s string) bool { return IsVersion(s, 8) }
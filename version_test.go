@@ -0,0 +1,47 @@
+package uid
+
+import "testing"
+
+func TestIsVersion(t *testing.T) {
+	predicates := map[int]func(string) bool{
+		1: IsV1, 3: IsV3, 4: IsV4, 5: IsV5, 6: IsV6, 7: IsV7, 8: IsV8,
+	}
+	v3, _ := UuidV3(NamespaceDNS, []byte("www.example.com"))
+	v5, _ := UuidV5(NamespaceDNS, []byte("www.example.com"), true)
+	ids := map[int]string{
+		1: UuidV1(),
+		3: v3,
+		4: UuidV4(true),
+		5: v5,
+		6: UuidV6(),
+		7: UuidV7(true),
+		8: UuidV8Linked(nil),
+	}
+	for version, id := range ids {
+		if got, err := Version(id); err != nil || got != version {
+			t.Fatalf("Version(%q) = %d, %v; want %d", id, got, err, version)
+		}
+		if !IsVersion(id, version) {
+			t.Fatalf("IsVersion(%q, %d) = false", id, version)
+		}
+		for v, is := range predicates {
+			if got := is(id); got != (v == version) {
+				t.Fatalf("IsV%d(%q) = %v", v, id, got)
+			}
+		}
+	}
+}
+
+func TestIsVersion_Invalid(t *testing.T) {
+	for _, s := range []string{"", "invalid", "01890a5d-ac96-774b-bcce-b302099a805"} {
+		if _, err := Version(s); err == nil {
+			t.Fatalf("Version(%q) expected error", s)
+		}
+		if IsVersion(s, 7) || IsV7(s) {
+			t.Fatalf("IsV7(%q) = true for invalid input", s)
+		}
+	}
+	if !IsVersion(NilUUID(), 0) || IsV1(NilUUID()) {
+		t.Fatal("the nil UUID has version 0")
+	}
+}